@@ -0,0 +1,3 @@
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS "accounts_balance_overdraft_check";
+
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "overdraft_limit";
\ No newline at end of file
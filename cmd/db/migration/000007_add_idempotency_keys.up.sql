@@ -0,0 +1,13 @@
+CREATE TABLE "idempotency_keys" (
+  "email" text NOT NULL,
+  "idempotency_key" text NOT NULL,
+  "request_hash" text NOT NULL,
+  "response_body" jsonb,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("email", "idempotency_key")
+);
+
+COMMENT ON COLUMN "idempotency_keys"."response_body" IS 'null while the first request with this key is in progress';
+
+ALTER TABLE "idempotency_keys" ADD FOREIGN KEY ("email") REFERENCES "users" ("email");
\ No newline at end of file
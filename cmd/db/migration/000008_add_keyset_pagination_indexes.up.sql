@@ -0,0 +1,5 @@
+CREATE INDEX "entries_account_id_id_idx" ON "entries" ("account_id", "id");
+
+CREATE INDEX "transfers_from_account_id_id_idx" ON "transfers" ("from_account_id", "id");
+
+CREATE INDEX "transfers_to_account_id_id_idx" ON "transfers" ("to_account_id", "id");
\ No newline at end of file
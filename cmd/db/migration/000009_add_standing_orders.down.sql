@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "standing_orders";
\ No newline at end of file
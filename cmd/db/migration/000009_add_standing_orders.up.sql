@@ -0,0 +1,32 @@
+CREATE TABLE "standing_orders" (
+  "id" bigserial PRIMARY KEY,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL CHECK ("amount" > 0),
+  "frequency" text NOT NULL CHECK ("frequency" IN ('once', 'daily', 'weekly', 'monthly')),
+  "start_at" timestamptz NOT NULL,
+  "end_at" timestamptz,
+  "status" text NOT NULL DEFAULT 'active' CHECK ("status" IN ('active', 'completed', 'failed', 'cancelled')),
+  "next_execution_at" timestamptz,
+  "execution_count" integer NOT NULL DEFAULT 0,
+  "failed_attempts" integer NOT NULL DEFAULT 0,
+  "last_error" text,
+  "last_transfer_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "standing_orders" ("from_account_id");
+
+CREATE INDEX "standing_orders_due_idx" ON "standing_orders" ("next_execution_at") WHERE "status" = 'active';
+
+COMMENT ON COLUMN "standing_orders"."next_execution_at" IS 'next attempt, also used for retries of a failed execution. null if the order is not active';
+
+COMMENT ON COLUMN "standing_orders"."execution_count" IS 'number of scheduled executions that are done, including executions that were skipped after too many failures';
+
+COMMENT ON COLUMN "standing_orders"."failed_attempts" IS 'failed attempts of the current execution';
+
+ALTER TABLE "standing_orders" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "standing_orders" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "standing_orders" ADD FOREIGN KEY ("last_transfer_id") REFERENCES "transfers" ("id");
\ No newline at end of file
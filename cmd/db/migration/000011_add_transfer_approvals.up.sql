@@ -0,0 +1,34 @@
+ALTER TABLE "accounts" ADD COLUMN "held_amount" bigint NOT NULL DEFAULT 0 CHECK ("held_amount" >= 0);
+
+COMMENT ON COLUMN "accounts"."held_amount" IS 'reserved for pending transfers, not available for new transfers';
+
+CREATE TABLE "transfer_approvals" (
+  "id" bigserial PRIMARY KEY,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL CHECK ("amount" > 0),
+  "initiator" text NOT NULL,
+  "status" text NOT NULL DEFAULT 'pending' CHECK ("status" IN ('pending', 'approved', 'rejected')),
+  "reviewer" text,
+  "transfer_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "decided_at" timestamptz
+);
+
+CREATE INDEX "transfer_approvals_pending_idx" ON "transfer_approvals" ("id") WHERE "status" = 'pending';
+
+COMMENT ON COLUMN "transfer_approvals"."amount" IS 'held on the sending account until the transfer is approved or rejected';
+
+COMMENT ON COLUMN "transfer_approvals"."reviewer" IS 'banker who approved or rejected the transfer, must not be the initiator';
+
+COMMENT ON COLUMN "transfer_approvals"."transfer_id" IS 'transfer that was executed after the approval';
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("initiator") REFERENCES "users" ("email");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("reviewer") REFERENCES "users" ("email");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
\ No newline at end of file
@@ -0,0 +1,7 @@
+ALTER TABLE "transfer_approvals" DROP COLUMN IF EXISTS "hold_id";
+
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "available_balance";
+
+COMMENT ON COLUMN "accounts"."held_amount" IS 'reserved for pending transfers, not available for new transfers';
+
+DROP TABLE IF EXISTS "holds";
\ No newline at end of file
@@ -0,0 +1,43 @@
+CREATE TABLE "holds" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL CHECK ("amount" > 0),
+  "description" text NOT NULL DEFAULT '',
+  "status" text NOT NULL DEFAULT 'active' CHECK ("status" IN ('active', 'captured', 'released', 'expired')),
+  "transfer_id" bigint,
+  "expires_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "closed_at" timestamptz
+);
+
+CREATE INDEX ON "holds" ("account_id", "id");
+
+CREATE INDEX "holds_expiry_idx" ON "holds" ("expires_at") WHERE "status" = 'active';
+
+COMMENT ON COLUMN "holds"."amount" IS 'reserved on the account while the hold is active';
+
+COMMENT ON COLUMN "holds"."transfer_id" IS 'transfer that captured the hold';
+
+COMMENT ON COLUMN "holds"."expires_at" IS 'the hold is released automatically after this time, null if it does not expire';
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "accounts" ADD COLUMN "available_balance" bigint GENERATED ALWAYS AS ("balance" - "held_amount") STORED;
+
+COMMENT ON COLUMN "accounts"."held_amount" IS 'sum of the active holds, not available for new transfers';
+
+COMMENT ON COLUMN "accounts"."available_balance" IS 'ledger balance minus the held amount';
+
+ALTER TABLE "transfer_approvals" ADD COLUMN "hold_id" bigint;
+
+COMMENT ON COLUMN "transfer_approvals"."hold_id" IS 'reserves the amount on the sending account until the transfer is approved or rejected';
+
+-- amounts of pending transfers were held without a hold before
+UPDATE "transfer_approvals" SET "hold_id" = nextval('holds_id_seq') WHERE "status" = 'pending';
+
+INSERT INTO "holds" ("id", "account_id", "amount", "description")
+SELECT "hold_id", "from_account_id", "amount", 'pending transfer to account ' || "to_account_id" FROM "transfer_approvals" WHERE "hold_id" IS NOT NULL;
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("hold_id") REFERENCES "holds" ("id");
\ No newline at end of file
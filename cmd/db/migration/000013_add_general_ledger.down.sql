@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "postings";
+
+DROP FUNCTION IF EXISTS "check_journal_balanced";
+
+DROP TABLE IF EXISTS "journals";
+
+DROP TABLE IF EXISTS "ledger_accounts";
\ No newline at end of file
@@ -0,0 +1,126 @@
+CREATE TABLE "ledger_accounts" (
+  "code" text PRIMARY KEY,
+  "name" text NOT NULL,
+  "type" text NOT NULL CHECK ("type" IN ('asset', 'liability', 'equity', 'income', 'expense')),
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "journals" (
+  "id" bigserial PRIMARY KEY,
+  "description" text NOT NULL,
+  "transfer_id" bigint UNIQUE,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "postings" (
+  "id" bigserial PRIMARY KEY,
+  "journal_id" bigint NOT NULL,
+  "ledger_account" text NOT NULL,
+  "account_id" bigint,
+  "amount" bigint NOT NULL CHECK ("amount" <> 0),
+  "currency" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "postings" ("journal_id");
+
+CREATE INDEX ON "postings" ("ledger_account", "currency");
+
+CREATE INDEX ON "postings" ("account_id");
+
+COMMENT ON TABLE "ledger_accounts" IS 'chart of accounts of the bank';
+
+COMMENT ON COLUMN "journals"."transfer_id" IS 'transfer that is booked by this journal, null for manual journals';
+
+COMMENT ON COLUMN "postings"."account_id" IS 'customer account of the posting, only for the customer deposits ledger account';
+
+COMMENT ON COLUMN "postings"."amount" IS 'debit if positive, credit if negative. The postings of a journal sum to zero per currency';
+
+ALTER TABLE "journals" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "postings" ADD FOREIGN KEY ("journal_id") REFERENCES "journals" ("id");
+
+ALTER TABLE "postings" ADD FOREIGN KEY ("ledger_account") REFERENCES "ledger_accounts" ("code");
+
+ALTER TABLE "postings" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+-- the check is deferred to the end of the transaction, so that the postings of a journal can be inserted one by one
+CREATE FUNCTION "check_journal_balanced"() RETURNS trigger AS $$
+DECLARE
+  unbalanced record;
+BEGIN
+  SELECT "currency", sum("amount") AS "total" INTO unbalanced
+  FROM "postings"
+  WHERE "journal_id" = NEW."journal_id"
+  GROUP BY "currency"
+  HAVING sum("amount") <> 0
+  LIMIT 1;
+
+  IF FOUND THEN
+    RAISE EXCEPTION 'journal % is not balanced, % postings sum to %', NEW."journal_id", unbalanced."currency", unbalanced."total";
+  END IF;
+
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER "postings_balanced"
+AFTER INSERT OR UPDATE ON "postings"
+DEFERRABLE INITIALLY DEFERRED
+FOR EACH ROW EXECUTE FUNCTION "check_journal_balanced"();
+
+INSERT INTO "ledger_accounts" ("code", "name", "type") VALUES
+  ('1000', 'Cash', 'asset'),
+  ('1500', 'Foreign exchange clearing', 'asset'),
+  ('1900', 'Suspense', 'asset'),
+  ('2000', 'Customer deposits', 'liability'),
+  ('3000', 'Equity', 'equity'),
+  ('4000', 'Fee income', 'income'),
+  ('5000', 'Interest expense', 'expense');
+
+-- book the existing transfers, every transfer debits the deposit of the sender and credits the deposit of the receiver.
+-- Transfers between currencies are balanced per currency by the foreign exchange clearing account
+INSERT INTO "journals" ("description", "transfer_id", "created_at")
+SELECT 'transfer ' || "id", "id", "created_at" FROM "transfers" ORDER BY "id";
+
+INSERT INTO "postings" ("journal_id", "ledger_account", "account_id", "amount", "currency", "created_at")
+SELECT "j"."id", '2000', "t"."from_account_id", "t"."amount", "fa"."currency", "t"."created_at"
+FROM "transfers" "t"
+JOIN "journals" "j" ON "j"."transfer_id" = "t"."id"
+JOIN "accounts" "fa" ON "fa"."id" = "t"."from_account_id"
+UNION ALL
+SELECT "j"."id", '2000', "t"."to_account_id", -"t"."to_amount", "ta"."currency", "t"."created_at"
+FROM "transfers" "t"
+JOIN "journals" "j" ON "j"."transfer_id" = "t"."id"
+JOIN "accounts" "ta" ON "ta"."id" = "t"."to_account_id"
+UNION ALL
+SELECT "j"."id", '1500', NULL, -"t"."amount", "fa"."currency", "t"."created_at"
+FROM "transfers" "t"
+JOIN "journals" "j" ON "j"."transfer_id" = "t"."id"
+JOIN "accounts" "fa" ON "fa"."id" = "t"."from_account_id"
+JOIN "accounts" "ta" ON "ta"."id" = "t"."to_account_id"
+WHERE "fa"."currency" <> "ta"."currency"
+UNION ALL
+SELECT "j"."id", '1500', NULL, "t"."to_amount", "ta"."currency", "t"."created_at"
+FROM "transfers" "t"
+JOIN "journals" "j" ON "j"."transfer_id" = "t"."id"
+JOIN "accounts" "fa" ON "fa"."id" = "t"."from_account_id"
+JOIN "accounts" "ta" ON "ta"."id" = "t"."to_account_id"
+WHERE "fa"."currency" <> "ta"."currency";
+
+-- balances that are not explained by transfers are booked against the suspense account until finance clears them
+WITH "opening" AS (
+  SELECT "a"."id", "a"."currency", "a"."balance" - COALESCE(sum("e"."amount"), 0) AS "amount"
+  FROM "accounts" "a"
+  LEFT JOIN "entries" "e" ON "e"."account_id" = "a"."id"
+  GROUP BY "a"."id"
+  HAVING "a"."balance" - COALESCE(sum("e"."amount"), 0) <> 0
+), "journal" AS (
+  INSERT INTO "journals" ("description")
+  SELECT 'opening balances' WHERE EXISTS (SELECT 1 FROM "opening")
+  RETURNING "id"
+)
+INSERT INTO "postings" ("journal_id", "ledger_account", "account_id", "amount", "currency")
+SELECT "journal"."id", '2000', "opening"."id", -"opening"."amount", "opening"."currency" FROM "journal", "opening"
+UNION ALL
+SELECT "journal"."id", '1900', NULL, "opening"."amount", "opening"."currency" FROM "journal", "opening";
\ No newline at end of file
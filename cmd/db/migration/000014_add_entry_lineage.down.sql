@@ -0,0 +1,7 @@
+ALTER TABLE "entries" DROP COLUMN IF EXISTS "category";
+
+ALTER TABLE "entries" DROP COLUMN IF EXISTS "description";
+
+ALTER TABLE "entries" DROP COLUMN IF EXISTS "journal_id";
+
+ALTER TABLE "entries" DROP COLUMN IF EXISTS "transfer_id";
\ No newline at end of file
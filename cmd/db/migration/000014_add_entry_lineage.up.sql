@@ -0,0 +1,42 @@
+ALTER TABLE "entries" ADD COLUMN "transfer_id" bigint;
+
+ALTER TABLE "entries" ADD COLUMN "journal_id" bigint;
+
+ALTER TABLE "entries" ADD COLUMN "description" text NOT NULL DEFAULT '';
+
+ALTER TABLE "entries" ADD COLUMN "category" text NOT NULL DEFAULT '';
+
+CREATE INDEX ON "entries" ("transfer_id");
+
+COMMENT ON COLUMN "entries"."transfer_id" IS 'transfer that produced the entry, null for manual journals';
+
+COMMENT ON COLUMN "entries"."journal_id" IS 'journal that booked the entry';
+
+COMMENT ON COLUMN "entries"."category" IS 'e.g. transfer, reversal or the category of a manual journal';
+
+ALTER TABLE "entries" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "entries" ADD FOREIGN KEY ("journal_id") REFERENCES "journals" ("id");
+
+-- entries were created in the same database transaction as their transfer and share its timestamp
+UPDATE "entries" "e"
+SET
+  "transfer_id" = "t"."id",
+  "category" = CASE WHEN "t"."reversal_of" IS NULL THEN 'transfer' ELSE 'reversal' END,
+  "description" = CASE
+    WHEN "e"."amount" < 0 THEN 'transfer to account ' || "t"."to_account_id"
+    ELSE 'transfer from account ' || "t"."from_account_id"
+  END
+FROM "transfers" "t"
+WHERE
+  "e"."created_at" = "t"."created_at"
+  AND (
+    ("e"."account_id" = "t"."from_account_id" AND "e"."amount" = -"t"."amount")
+    OR
+    ("e"."account_id" = "t"."to_account_id" AND "e"."amount" = "t"."to_amount")
+  );
+
+UPDATE "entries" "e"
+SET "journal_id" = "j"."id"
+FROM "journals" "j"
+WHERE "j"."transfer_id" = "e"."transfer_id";
\ No newline at end of file
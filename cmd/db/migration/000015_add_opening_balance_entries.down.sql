@@ -0,0 +1 @@
+DELETE FROM "entries" WHERE "category" = 'opening_balance';
\ No newline at end of file
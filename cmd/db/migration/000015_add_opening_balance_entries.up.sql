@@ -0,0 +1,11 @@
+-- balances that the general ledger migration booked against the suspense account had no entries, so that the balance
+-- of these accounts did not equal the sum of their entries
+INSERT INTO "entries" ("account_id", "amount", "journal_id", "description", "category", "created_at")
+SELECT "p"."account_id", -"p"."amount", "p"."journal_id", 'opening balance', 'opening_balance', "j"."created_at"
+FROM "postings" "p"
+JOIN "journals" "j" ON "j"."id" = "p"."journal_id"
+WHERE
+  "j"."description" = 'opening balances'
+  AND "j"."transfer_id" IS NULL
+  AND "p"."account_id" IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM "entries" "e" WHERE "e"."journal_id" = "p"."journal_id");
\ No newline at end of file
@@ -0,0 +1,17 @@
+CREATE TABLE "balance_snapshots" (
+  "account_id" bigint NOT NULL,
+  "end_time" timestamptz NOT NULL,
+  "balance" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("account_id", "end_time")
+);
+
+COMMENT ON TABLE "balance_snapshots" IS 'daily balances of the accounts, computed from the entries';
+
+COMMENT ON COLUMN "balance_snapshots"."end_time" IS 'start of the next day in UTC, the balance is the sum of the entries created before';
+
+CREATE INDEX ON "balance_snapshots" ("end_time");
+
+ALTER TABLE "balance_snapshots" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+
+CREATE INDEX "entries_account_id_created_at_idx" ON "entries" ("account_id", "created_at");
\ No newline at end of file
@@ -0,0 +1 @@
+-- the entries explain the balances of the accounts, which are not changed by the migration, so they are kept
\ No newline at end of file
@@ -0,0 +1,22 @@
+-- the postings of a manual journal on a customer account are explained by the entries of the same journal. Opening
+-- balances that the general ledger migration booked without entries get the missing entry, linked by the journal of
+-- their postings. Transfer journals are left to the reconciliation of the transfers
+WITH "missing" AS (
+  SELECT
+    "p"."journal_id",
+    "p"."account_id",
+    -sum("p"."amount") - COALESCE((
+      SELECT sum("e"."amount")
+      FROM "entries" "e"
+      WHERE "e"."journal_id" = "p"."journal_id" AND "e"."account_id" = "p"."account_id"
+    ), 0) AS "amount"
+  FROM "postings" "p"
+  JOIN "journals" "j" ON "j"."id" = "p"."journal_id"
+  WHERE "p"."account_id" IS NOT NULL AND "j"."transfer_id" IS NULL
+  GROUP BY "p"."journal_id", "p"."account_id"
+)
+INSERT INTO "entries" ("account_id", "amount", "journal_id", "description", "category", "created_at")
+SELECT "m"."account_id", "m"."amount", "m"."journal_id", 'opening balance', 'opening_balance', "j"."created_at"
+FROM "missing" "m"
+JOIN "journals" "j" ON "j"."id" = "m"."journal_id"
+WHERE "m"."amount" <> 0;
\ No newline at end of file
@@ -0,0 +1,7 @@
+ALTER TABLE "transfers" ALTER COLUMN "exchange_rate" DROP DEFAULT;
+
+ALTER TABLE "transfers" ALTER COLUMN "exchange_rate" TYPE double precision USING "exchange_rate" / 100000000.0;
+
+ALTER TABLE "transfers" ALTER COLUMN "exchange_rate" SET DEFAULT 1;
+
+COMMENT ON COLUMN "transfers"."exchange_rate" IS 'rate used to convert amount into to_amount';
\ No newline at end of file
@@ -0,0 +1,7 @@
+ALTER TABLE "transfers" ALTER COLUMN "exchange_rate" DROP DEFAULT;
+
+ALTER TABLE "transfers" ALTER COLUMN "exchange_rate" TYPE bigint USING round("exchange_rate" * 100000000)::bigint;
+
+ALTER TABLE "transfers" ALTER COLUMN "exchange_rate" SET DEFAULT 100000000;
+
+COMMENT ON COLUMN "transfers"."exchange_rate" IS 'rate used to convert amount into to_amount, in units of 10^-8';
\ No newline at end of file
@@ -0,0 +1,6 @@
+ALTER TABLE "idempotency_keys" ADD COLUMN "locked_until" timestamptz;
+
+-- reservations of requests that were in progress during the migration can be taken over right away
+UPDATE "idempotency_keys" SET "locked_until" = now() WHERE "response_body" IS NULL;
+
+COMMENT ON COLUMN "idempotency_keys"."locked_until" IS 'lease of the request in progress, another request with the key can take over after it, null once the response is stored';
\ No newline at end of file
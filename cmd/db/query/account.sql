@@ -37,12 +37,26 @@ SELECT
   *
 FROM
   accounts
+WHERE
+  id > sqlc.arg(after_id)
 ORDER BY
   id
 LIMIT
-  $1
-OFFSET
-  $2;
+  sqlc.arg('limit');
+
+-- name: ListAccountsByOwner :many
+SELECT
+  *
+FROM
+  accounts
+WHERE
+  owner = sqlc.arg(owner)
+  AND
+  id > sqlc.arg(after_id)
+ORDER BY
+  id
+LIMIT
+  sqlc.arg('limit');
 
 -- name: UpdateAccount :one
 UPDATE
@@ -64,8 +78,28 @@ WHERE
 RETURNING
   *;
 
+-- name: AddAccountHeldAmount :one
+UPDATE
+  accounts
+SET
+  held_amount = held_amount + sqlc.arg(amount)
+WHERE
+  id = sqlc.arg(id)
+RETURNING
+  *;
+
 -- name: DeleteAccount :exec
 DELETE FROM
   accounts
 WHERE
-  id = $1;
\ No newline at end of file
+  id = $1;
+
+-- name: UpdateAccountOverdraftLimit :one
+UPDATE
+  accounts
+SET
+  overdraft_limit = $2
+WHERE
+  id = $1
+RETURNING
+  *;
\ No newline at end of file
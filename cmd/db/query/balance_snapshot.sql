@@ -0,0 +1,70 @@
+-- name: CreateBalanceSnapshots :execrows
+INSERT INTO
+  balance_snapshots (
+    account_id,
+    end_time,
+    balance
+  )
+SELECT
+  accounts.id,
+  sqlc.arg(end_time)::timestamptz,
+  COALESCE(previous.balance, 0) + COALESCE(sum(entries.amount), 0)
+FROM
+  accounts
+  LEFT JOIN balance_snapshots previous ON previous.account_id = accounts.id AND previous.end_time = sqlc.arg(previous_end_time)
+  -- all entries are summed if there is no previous snapshot
+  LEFT JOIN entries ON entries.account_id = accounts.id
+    AND entries.created_at < sqlc.arg(end_time)
+    AND (previous.end_time IS NULL OR entries.created_at >= previous.end_time)
+WHERE
+  accounts.created_at < sqlc.arg(end_time)
+GROUP BY
+  accounts.id,
+  previous.balance
+ON CONFLICT (account_id, end_time) DO NOTHING;
+
+-- name: GetLatestBalanceSnapshotTime :one
+SELECT
+  end_time
+FROM
+  balance_snapshots
+ORDER BY
+  end_time DESC
+LIMIT
+  1;
+
+-- name: GetFirstEntryTime :one
+SELECT
+  created_at
+FROM
+  entries
+ORDER BY
+  created_at
+LIMIT
+  1;
+
+-- name: GetBalanceSnapshotBefore :one
+SELECT
+  *
+FROM
+  balance_snapshots
+WHERE
+  account_id = sqlc.arg(account_id)
+  AND
+  end_time <= sqlc.arg(at)
+ORDER BY
+  end_time DESC
+LIMIT
+  1;
+
+-- name: SumEntriesUntil :one
+SELECT
+  COALESCE(sum(amount), 0)::bigint AS total
+FROM
+  entries
+WHERE
+  account_id = sqlc.arg(account_id)
+  AND
+  (sqlc.narg(start_time)::timestamptz IS NULL OR created_at >= sqlc.narg(start_time))
+  AND
+  created_at <= sqlc.arg(at);
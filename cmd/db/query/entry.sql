@@ -2,10 +2,14 @@
 INSERT INTO
   entries (
     account_id,
-    amount
+    amount,
+    transfer_id,
+    journal_id,
+    description,
+    category
   )
 VALUES (
-  $1, $2
+  $1, $2, $3, $4, $5, $6
 )
 RETURNING
   *;
@@ -26,10 +30,20 @@ SELECT
 FROM
   entries
 WHERE
-  account_id = $1
+  account_id = sqlc.arg(account_id)
+  AND
+  (
+    (amount < 0 AND sqlc.arg(outgoing)::boolean)
+    OR
+    (amount > 0 AND sqlc.arg(incoming)::boolean)
+  )
+  AND
+  (sqlc.narg(start_time)::timestamptz IS NULL OR created_at >= sqlc.narg(start_time))
+  AND
+  (sqlc.narg(end_time)::timestamptz IS NULL OR created_at < sqlc.narg(end_time))
+  AND
+  id > sqlc.arg(after_id)
 ORDER BY
   id
 LIMIT
-  $2
-OFFSET
-  $3;
\ No newline at end of file
+  sqlc.arg('limit');
\ No newline at end of file
@@ -0,0 +1,74 @@
+-- name: CreateHold :one
+INSERT INTO
+  holds (
+    account_id,
+    amount,
+    description,
+    expires_at
+  )
+VALUES (
+  $1, $2, $3, $4
+)
+RETURNING
+  *;
+
+-- name: GetHold :one
+SELECT
+  *
+FROM
+  holds
+WHERE
+  id = $1
+LIMIT
+  1;
+
+-- name: GetHoldForUpdate :one
+SELECT
+  *
+FROM
+  holds
+WHERE
+  id = $1
+LIMIT
+  1
+FOR NO KEY UPDATE;
+
+-- name: ListHoldsByAccount :many
+SELECT
+  *
+FROM
+  holds
+WHERE
+  account_id = sqlc.arg(account_id)
+  AND
+  id > sqlc.arg(after_id)
+ORDER BY
+  id
+LIMIT
+  sqlc.arg('limit');
+
+-- name: ListExpiredHolds :many
+SELECT
+  *
+FROM
+  holds
+WHERE
+  status = 'active'
+  AND
+  expires_at <= sqlc.arg(now)
+ORDER BY
+  expires_at
+LIMIT
+  sqlc.arg('limit');
+
+-- name: CloseHold :one
+UPDATE
+  holds
+SET
+  status = $2,
+  transfer_id = $3,
+  closed_at = now()
+WHERE
+  id = $1
+RETURNING
+  *;
\ No newline at end of file
@@ -0,0 +1,65 @@
+-- name: CreateIdempotencyKey :one
+INSERT INTO
+  idempotency_keys (
+    email,
+    idempotency_key,
+    request_hash,
+    expires_at,
+    locked_until
+  )
+VALUES (
+  $1, $2, $3, $4, $5
+)
+-- a reservation whose lease ran out was left by a request that did not finish, it is taken over
+ON CONFLICT (email, idempotency_key) DO UPDATE
+SET
+  request_hash = EXCLUDED.request_hash,
+  expires_at = EXCLUDED.expires_at,
+  locked_until = EXCLUDED.locked_until
+WHERE
+  idempotency_keys.response_body IS NULL
+  AND
+  idempotency_keys.locked_until <= now()
+RETURNING
+  *;
+
+-- name: GetIdempotencyKey :one
+SELECT
+  *
+FROM
+  idempotency_keys
+WHERE
+  email = $1
+  AND
+  idempotency_key = $2
+LIMIT
+  1;
+
+-- name: CompleteIdempotencyKey :exec
+UPDATE
+  idempotency_keys
+SET
+  response_body = $3,
+  locked_until = NULL
+WHERE
+  email = $1
+  AND
+  idempotency_key = $2;
+
+-- name: DeleteIdempotencyKey :exec
+DELETE FROM
+  idempotency_keys
+WHERE
+  email = $1
+  AND
+  idempotency_key = $2;
+
+-- name: DeleteExpiredIdempotencyKey :exec
+DELETE FROM
+  idempotency_keys
+WHERE
+  email = $1
+  AND
+  idempotency_key = $2
+  AND
+  expires_at <= now();
\ No newline at end of file
@@ -0,0 +1,87 @@
+-- name: CreateLedgerAccount :one
+INSERT INTO
+  ledger_accounts (
+    code,
+    name,
+    type
+  )
+VALUES (
+  $1, $2, $3
+)
+RETURNING
+  *;
+
+-- name: ListLedgerAccounts :many
+SELECT
+  *
+FROM
+  ledger_accounts
+ORDER BY
+  code;
+
+-- name: CreateJournal :one
+INSERT INTO
+  journals (
+    description,
+    transfer_id
+  )
+VALUES (
+  $1, $2
+)
+RETURNING
+  *;
+
+-- name: GetJournal :one
+SELECT
+  *
+FROM
+  journals
+WHERE
+  id = $1
+LIMIT
+  1;
+
+-- name: CreatePosting :one
+INSERT INTO
+  postings (
+    journal_id,
+    ledger_account,
+    account_id,
+    amount,
+    currency
+  )
+VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING
+  *;
+
+-- name: ListPostingsByJournal :many
+SELECT
+  *
+FROM
+  postings
+WHERE
+  journal_id = $1
+ORDER BY
+  id;
+
+-- name: GetTrialBalance :many
+SELECT
+  ledger_accounts.code AS ledger_account,
+  ledger_accounts.name,
+  ledger_accounts.type,
+  postings.currency,
+  COALESCE(sum(postings.amount) FILTER (WHERE postings.amount > 0), 0)::bigint AS debit,
+  COALESCE(-sum(postings.amount) FILTER (WHERE postings.amount < 0), 0)::bigint AS credit
+FROM
+  ledger_accounts
+  JOIN postings ON postings.ledger_account = ledger_accounts.code
+WHERE
+  sqlc.narg(end_time)::timestamptz IS NULL OR postings.created_at < sqlc.narg(end_time)
+GROUP BY
+  ledger_accounts.code,
+  postings.currency
+ORDER BY
+  ledger_accounts.code,
+  postings.currency;
\ No newline at end of file
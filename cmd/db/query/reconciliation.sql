@@ -0,0 +1,71 @@
+-- name: ListBalanceDrifts :many
+SELECT
+  accounts.id AS account_id,
+  accounts.balance,
+  COALESCE(entry_totals.total, 0)::bigint AS entries_total,
+  COALESCE(posting_totals.total, 0)::bigint AS postings_total
+FROM
+  accounts
+  LEFT JOIN (
+    SELECT account_id, sum(amount) AS total FROM entries GROUP BY account_id
+  ) entry_totals ON entry_totals.account_id = accounts.id
+  -- customer accounts are credited on the customer deposits ledger account
+  LEFT JOIN (
+    SELECT account_id, -sum(amount) AS total FROM postings WHERE ledger_account = '2000' GROUP BY account_id
+  ) posting_totals ON posting_totals.account_id = accounts.id
+WHERE
+  accounts.balance <> COALESCE(entry_totals.total, 0)
+  OR
+  accounts.balance <> COALESCE(posting_totals.total, 0)
+ORDER BY
+  accounts.id;
+
+-- name: ListUnbalancedTransfers :many
+SELECT
+  transfers.id AS transfer_id,
+  transfers.from_account_id,
+  transfers.to_account_id,
+  transfers.amount,
+  transfers.to_amount,
+  COALESCE(sum(entries.amount) FILTER (WHERE entries.account_id = transfers.from_account_id AND entries.amount < 0), 0)::bigint AS from_entries_total,
+  COALESCE(sum(entries.amount) FILTER (WHERE entries.account_id = transfers.to_account_id AND entries.amount > 0), 0)::bigint AS to_entries_total,
+  count(entries.id) AS entry_count
+FROM
+  transfers
+  -- the sender is debited and the receiver credited, the sign keeps both entries of a transfer to the same account apart
+  LEFT JOIN entries ON entries.transfer_id = transfers.id
+GROUP BY
+  transfers.id
+HAVING
+  COALESCE(sum(entries.amount) FILTER (WHERE entries.account_id = transfers.from_account_id AND entries.amount < 0), 0) <> -transfers.amount
+  OR
+  COALESCE(sum(entries.amount) FILTER (WHERE entries.account_id = transfers.to_account_id AND entries.amount > 0), 0) <> transfers.to_amount
+  OR
+  count(entries.id) <> 2
+ORDER BY
+  transfers.id;
+
+-- name: ListOrphanEntries :many
+SELECT
+  *
+FROM
+  entries
+WHERE
+  (transfer_id IS NULL AND journal_id IS NULL)
+  OR
+  (
+    transfer_id IS NOT NULL
+    AND
+    NOT EXISTS (
+      SELECT
+        1
+      FROM
+        transfers
+      WHERE
+        transfers.id = entries.transfer_id
+        AND
+        entries.account_id IN (transfers.from_account_id, transfers.to_account_id)
+    )
+  )
+ORDER BY
+  id;
@@ -23,4 +23,34 @@ FROM
 WHERE
   id = $1
 LIMIT
- 1;
\ No newline at end of file
+ 1;
+
+-- name: BlockSession :exec
+UPDATE
+  sessions
+SET
+  is_blocked = true
+WHERE
+  id = $1;
+
+-- name: BlockSessionsByEmail :exec
+UPDATE
+  sessions
+SET
+  is_blocked = true
+WHERE
+  email = $1;
+
+-- name: ListActiveSessions :many
+SELECT
+  *
+FROM
+  sessions
+WHERE
+  email = $1
+  AND
+  is_blocked = false
+  AND
+  expires_at > now()
+ORDER BY
+  created_at DESC;
\ No newline at end of file
@@ -0,0 +1,87 @@
+-- name: CreateStandingOrder :one
+INSERT INTO
+  standing_orders (
+    from_account_id,
+    to_account_id,
+    amount,
+    frequency,
+    start_at,
+    end_at,
+    next_execution_at
+  )
+VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING
+  *;
+
+-- name: GetStandingOrder :one
+SELECT
+  *
+FROM
+  standing_orders
+WHERE
+  id = $1
+LIMIT 1;
+
+-- name: ListDueStandingOrders :many
+SELECT
+  *
+FROM
+  standing_orders
+WHERE
+  status = 'active'
+  AND
+  next_execution_at <= sqlc.arg(now)
+ORDER BY
+  next_execution_at
+LIMIT
+  sqlc.arg('limit');
+
+-- name: ListStandingOrdersByOwner :many
+SELECT
+  *
+FROM
+  standing_orders
+WHERE
+  from_account_id IN (SELECT id FROM accounts WHERE owner = sqlc.arg(owner))
+  AND
+  id > sqlc.arg(after_id)
+ORDER BY
+  id
+LIMIT
+  sqlc.arg('limit');
+
+-- name: UpdateStandingOrder :one
+UPDATE
+  standing_orders
+SET
+  amount = sqlc.arg(amount),
+  end_at = sqlc.narg(end_at),
+  status = sqlc.arg(status),
+  next_execution_at = sqlc.narg(next_execution_at)
+WHERE
+  id = sqlc.arg(id)
+  AND
+  next_execution_at IS NOT DISTINCT FROM sqlc.narg(expected_next_execution_at)
+RETURNING
+  *;
+
+-- name: UpdateStandingOrderExecution :one
+UPDATE
+  standing_orders
+SET
+  status = sqlc.arg(status),
+  next_execution_at = sqlc.narg(next_execution_at),
+  execution_count = sqlc.arg(execution_count),
+  failed_attempts = sqlc.arg(failed_attempts),
+  last_error = sqlc.narg(last_error),
+  last_transfer_id = COALESCE(sqlc.narg(last_transfer_id), last_transfer_id)
+WHERE
+  id = sqlc.arg(id)
+  AND
+  status = 'active'
+  AND
+  next_execution_at = sqlc.arg(expected_next_execution_at)
+RETURNING
+  *;
\ No newline at end of file
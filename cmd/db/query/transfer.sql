@@ -3,10 +3,13 @@ INSERT INTO
   transfers (
     from_account_id,
     to_account_id,
-    amount
+    amount,
+    to_amount,
+    exchange_rate,
+    reversal_of
   )
 VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4, $5, $6
 )
 RETURNING
   *;
@@ -20,18 +23,43 @@ WHERE
   id = $1
 LIMIT 1;
 
+-- name: GetTransferForUpdate :one
+SELECT
+  *
+FROM
+  transfers
+WHERE
+  id = $1
+LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: GetReversedAmount :one
+SELECT
+  COALESCE(SUM(amount), 0)::bigint AS amount,
+  COALESCE(SUM(to_amount), 0)::bigint AS to_amount
+FROM
+  transfers
+WHERE
+  reversal_of = sqlc.arg(transfer_id)::bigint;
+
 -- name: ListTransfers :many
 SELECT
   *
 FROM
   transfers
-WHERE 
-  from_account_id = $1
-  OR
-  to_account_id = $2
+WHERE
+  (
+    (from_account_id = sqlc.arg(account_id) AND sqlc.arg(outgoing)::boolean)
+    OR
+    (to_account_id = sqlc.arg(account_id) AND sqlc.arg(incoming)::boolean)
+  )
+  AND
+  (sqlc.narg(start_time)::timestamptz IS NULL OR created_at >= sqlc.narg(start_time))
+  AND
+  (sqlc.narg(end_time)::timestamptz IS NULL OR created_at < sqlc.narg(end_time))
+  AND
+  id > sqlc.arg(after_id)
 ORDER BY
   id
 LIMIT
-  $3
-OFFSET
-  $4;
\ No newline at end of file
+  sqlc.arg('limit');
\ No newline at end of file
@@ -0,0 +1,72 @@
+-- name: CreateTransferApproval :one
+INSERT INTO
+  transfer_approvals (
+    from_account_id,
+    to_account_id,
+    amount,
+    initiator,
+    hold_id
+  )
+VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING
+  *;
+
+-- name: GetTransferApproval :one
+SELECT
+  *
+FROM
+  transfer_approvals
+WHERE
+  id = $1
+LIMIT
+  1;
+
+-- name: GetTransferApprovalByHold :one
+SELECT
+  *
+FROM
+  transfer_approvals
+WHERE
+  hold_id = $1
+LIMIT
+  1;
+
+-- name: GetTransferApprovalForUpdate :one
+SELECT
+  *
+FROM
+  transfer_approvals
+WHERE
+  id = $1
+LIMIT
+  1
+FOR NO KEY UPDATE;
+
+-- name: ListPendingTransferApprovals :many
+SELECT
+  *
+FROM
+  transfer_approvals
+WHERE
+  status = 'pending'
+  AND
+  id > sqlc.arg(after_id)
+ORDER BY
+  id
+LIMIT
+  sqlc.arg('limit');
+
+-- name: DecideTransferApproval :one
+UPDATE
+  transfer_approvals
+SET
+  status = $2,
+  reviewer = $3,
+  transfer_id = $4,
+  decided_at = now()
+WHERE
+  id = $1
+RETURNING
+  *;
\ No newline at end of file
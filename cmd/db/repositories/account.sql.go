@@ -17,7 +17,7 @@ SET
 WHERE
   id = $2
 RETURNING
-  id, owner, balance, currency, created_at
+  id, owner, balance, currency, created_at, overdraft_limit, held_amount, available_balance
 `
 
 type AddAccountBalanceParams struct {
@@ -34,6 +34,41 @@ func (q *Queries) AddAccountBalance(ctx context.Context, arg *AddAccountBalanceP
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.HeldAmount,
+		&i.AvailableBalance,
+	)
+	return &i, err
+}
+
+const addAccountHeldAmount = `-- name: AddAccountHeldAmount :one
+UPDATE
+  accounts
+SET
+  held_amount = held_amount + $1
+WHERE
+  id = $2
+RETURNING
+  id, owner, balance, currency, created_at, overdraft_limit, held_amount, available_balance
+`
+
+type AddAccountHeldAmountParams struct {
+	Amount int64 `json:"amount"`
+	ID     int64 `json:"id"`
+}
+
+func (q *Queries) AddAccountHeldAmount(ctx context.Context, arg *AddAccountHeldAmountParams) (*Account, error) {
+	row := q.db.QueryRow(ctx, addAccountHeldAmount, arg.Amount, arg.ID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.HeldAmount,
+		&i.AvailableBalance,
 	)
 	return &i, err
 }
@@ -49,7 +84,7 @@ VALUES (
   $1, $2, $3
 )
 RETURNING
-  id, owner, balance, currency, created_at
+  id, owner, balance, currency, created_at, overdraft_limit, held_amount, available_balance
 `
 
 type CreateAccountParams struct {
@@ -67,6 +102,9 @@ func (q *Queries) CreateAccount(ctx context.Context, arg *CreateAccountParams) (
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.HeldAmount,
+		&i.AvailableBalance,
 	)
 	return &i, err
 }
@@ -85,7 +123,7 @@ func (q *Queries) DeleteAccount(ctx context.Context, id int64) error {
 
 const getAccount = `-- name: GetAccount :one
 SELECT
-  id, owner, balance, currency, created_at
+  id, owner, balance, currency, created_at, overdraft_limit, held_amount, available_balance
 FROM
   accounts
 WHERE
@@ -103,13 +141,16 @@ func (q *Queries) GetAccount(ctx context.Context, id int64) (*Account, error) {
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.HeldAmount,
+		&i.AvailableBalance,
 	)
 	return &i, err
 }
 
 const getAccountForUpdate = `-- name: GetAccountForUpdate :one
 SELECT
-  id, owner, balance, currency, created_at
+  id, owner, balance, currency, created_at, overdraft_limit, held_amount, available_balance
 FROM
   accounts
 WHERE
@@ -128,30 +169,83 @@ func (q *Queries) GetAccountForUpdate(ctx context.Context, id int64) (*Account,
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.HeldAmount,
+		&i.AvailableBalance,
 	)
 	return &i, err
 }
 
 const listAccounts = `-- name: ListAccounts :many
 SELECT
-  id, owner, balance, currency, created_at
+  id, owner, balance, currency, created_at, overdraft_limit, held_amount, available_balance
 FROM
   accounts
+WHERE
+  id > $1
 ORDER BY
   id
 LIMIT
-  $1
-OFFSET
   $2
 `
 
 type ListAccountsParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	AfterID int64 `json:"after_id"`
+	Limit   int32 `json:"limit"`
 }
 
 func (q *Queries) ListAccounts(ctx context.Context, arg *ListAccountsParams) ([]*Account, error) {
-	rows, err := q.db.Query(ctx, listAccounts, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listAccounts, arg.AfterID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*Account
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Balance,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.OverdraftLimit,
+			&i.HeldAmount,
+			&i.AvailableBalance,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccountsByOwner = `-- name: ListAccountsByOwner :many
+SELECT
+  id, owner, balance, currency, created_at, overdraft_limit, held_amount, available_balance
+FROM
+  accounts
+WHERE
+  owner = $1
+  AND
+  id > $2
+ORDER BY
+  id
+LIMIT
+  $3
+`
+
+type ListAccountsByOwnerParams struct {
+	Owner   string `json:"owner"`
+	AfterID int64  `json:"after_id"`
+	Limit   int32  `json:"limit"`
+}
+
+func (q *Queries) ListAccountsByOwner(ctx context.Context, arg *ListAccountsByOwnerParams) ([]*Account, error) {
+	rows, err := q.db.Query(ctx, listAccountsByOwner, arg.Owner, arg.AfterID, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -165,6 +259,9 @@ func (q *Queries) ListAccounts(ctx context.Context, arg *ListAccountsParams) ([]
 			&i.Balance,
 			&i.Currency,
 			&i.CreatedAt,
+			&i.OverdraftLimit,
+			&i.HeldAmount,
+			&i.AvailableBalance,
 		); err != nil {
 			return nil, err
 		}
@@ -184,7 +281,7 @@ SET
 WHERE
   id = $1
 RETURNING
-  id, owner, balance, currency, created_at
+  id, owner, balance, currency, created_at, overdraft_limit, held_amount, available_balance
 `
 
 type UpdateAccountParams struct {
@@ -201,6 +298,41 @@ func (q *Queries) UpdateAccount(ctx context.Context, arg *UpdateAccountParams) (
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.HeldAmount,
+		&i.AvailableBalance,
+	)
+	return &i, err
+}
+
+const updateAccountOverdraftLimit = `-- name: UpdateAccountOverdraftLimit :one
+UPDATE
+  accounts
+SET
+  overdraft_limit = $2
+WHERE
+  id = $1
+RETURNING
+  id, owner, balance, currency, created_at, overdraft_limit, held_amount, available_balance
+`
+
+type UpdateAccountOverdraftLimitParams struct {
+	ID             int64 `json:"id"`
+	OverdraftLimit int64 `json:"overdraft_limit"`
+}
+
+func (q *Queries) UpdateAccountOverdraftLimit(ctx context.Context, arg *UpdateAccountOverdraftLimitParams) (*Account, error) {
+	row := q.db.QueryRow(ctx, updateAccountOverdraftLimit, arg.ID, arg.OverdraftLimit)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.HeldAmount,
+		&i.AvailableBalance,
 	)
 	return &i, err
 }
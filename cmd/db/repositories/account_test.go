@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"errors"
 	"strconv"
 	"testing"
 	"time"
@@ -59,6 +60,53 @@ func (suite *AccountTestSuite) TestCreateAccount() {
 	require.NotZero(suite.T(), account.CreatedAt)
 }
 
+func (suite *AccountTestSuite) TestCreateAccountTx() {
+	user := registerTestUser(suite.T(), &RegisterUserParams{
+		Email:          "Max@Mustermann.de",
+		HashedPassword: "",
+		FirstName:      "Max",
+		LastName:       "Mustermann",
+	})
+
+	createAccountParam := CreateAccountParams{
+		Owner:    user.Email,
+		Balance:  0,
+		Currency: "EUR",
+	}
+
+	var created *Account
+
+	account, err := testStore.CreateAccountTx(suite.ctx, CreateAccountTxParams{
+		CreateAccountParams: createAccountParam,
+		AfterCreate: func(q *Queries, account *Account) error {
+			created = account
+			return nil
+		},
+	})
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), account, created)
+
+	// an error of the hook rolls back the account
+	_, err = testStore.CreateAccountTx(suite.ctx, CreateAccountTxParams{
+		CreateAccountParams: CreateAccountParams{
+			Owner:    user.Email,
+			Balance:  0,
+			Currency: "USD",
+		},
+		AfterCreate: func(q *Queries, account *Account) error {
+			return errors.New("hook failed")
+		},
+	})
+	require.EqualError(suite.T(), err, "hook failed")
+
+	accounts, err := testStore.ListAccountsByOwner(suite.ctx, &ListAccountsByOwnerParams{
+		Owner: user.Email,
+		Limit: 10,
+	})
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), accounts, 1)
+}
+
 func (suite *AccountTestSuite) TestGetAccount() {
 	registerUserParam := &RegisterUserParams{
 		Email:          "Max@Mustermann.de",
@@ -149,6 +197,7 @@ func (suite *AccountTestSuite) TestDeleteAccount() {
 }
 
 func (suite *AccountTestSuite) TestListAccounts() {
+	var createdAccounts []*Account
 	for i := 0; i < 10; i++ {
 		email := "Max" + strconv.Itoa(i) + "@Mustermann.de"
 		registerUserParam := &RegisterUserParams{
@@ -165,23 +214,45 @@ func (suite *AccountTestSuite) TestListAccounts() {
 			Currency: "EUR",
 		}
 
-		_ = createTestAccount(suite.T(), arg)
+		createdAccounts = append(createdAccounts, createTestAccount(suite.T(), arg))
 	}
 
+	// the second page starts behind the fifth account
 	arg := ListAccountsParams{
-		Limit:  5,
-		Offset: 5,
+		AfterID: createdAccounts[4].ID,
+		Limit:   10,
 	}
 
 	accounts, err := testStore.ListAccounts(suite.ctx, &arg)
 	require.NoError(suite.T(), err)
 	require.Len(suite.T(), accounts, 5)
 
-	for _, account := range accounts {
-		require.NotEmpty(suite.T(), account)
+	for i, account := range accounts {
+		require.Equal(suite.T(), createdAccounts[i+5].ID, account.ID)
 	}
 }
 
+func (suite *AccountTestSuite) TestBalanceOverdraftConstraint() {
+	user := registerTestUser(suite.T(), &RegisterUserParams{
+		Email:          "Max@Mustermann.de",
+		HashedPassword: "",
+		FirstName:      "Max",
+		LastName:       "Mustermann",
+	})
+
+	account := createTestAccount(suite.T(), CreateAccountParams{
+		Owner:    user.Email,
+		Balance:  0,
+		Currency: "EUR",
+	})
+
+	// the account has no overdraft limit, so a negative balance violates the balance constraint
+	_, err := testStore.SetAccountBalance(suite.ctx, account.ID, -1)
+	require.Error(suite.T(), err)
+	require.Equal(suite.T(), CheckViolation, ErrorCode(err))
+	require.Equal(suite.T(), BalanceOverdraftConstraint, ConstraintName(err))
+}
+
 func createTestAccount(t *testing.T, arg CreateAccountParams) *Account {
 	account, err := testStore.CreateAccount(context.Background(), &arg)
 
@@ -7,41 +7,61 @@ package db
 
 import (
 	"context"
+	"time"
 )
 
 const createEntry = `-- name: CreateEntry :one
 INSERT INTO
   entries (
     account_id,
-    amount
+    amount,
+    transfer_id,
+    journal_id,
+    description,
+    category
   )
 VALUES (
-  $1, $2
+  $1, $2, $3, $4, $5, $6
 )
 RETURNING
-  id, account_id, amount, created_at
+  id, account_id, amount, created_at, transfer_id, journal_id, description, category
 `
 
 type CreateEntryParams struct {
-	AccountID int64 `json:"account_id"`
-	Amount    int64 `json:"amount"`
+	AccountID   int64  `json:"account_id"`
+	Amount      int64  `json:"amount"`
+	TransferID  *int64 `json:"transfer_id"`
+	JournalID   *int64 `json:"journal_id"`
+	Description string `json:"description"`
+	Category    string `json:"category"`
 }
 
 func (q *Queries) CreateEntry(ctx context.Context, arg *CreateEntryParams) (*Entry, error) {
-	row := q.db.QueryRow(ctx, createEntry, arg.AccountID, arg.Amount)
+	row := q.db.QueryRow(ctx, createEntry,
+		arg.AccountID,
+		arg.Amount,
+		arg.TransferID,
+		arg.JournalID,
+		arg.Description,
+		arg.Category,
+	)
 	var i Entry
 	err := row.Scan(
 		&i.ID,
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.TransferID,
+		&i.JournalID,
+		&i.Description,
+		&i.Category,
 	)
 	return &i, err
 }
 
 const getEntry = `-- name: GetEntry :one
 SELECT
-  id, account_id, amount, created_at
+  id, account_id, amount, created_at, transfer_id, journal_id, description, category
 FROM
   entries
 WHERE
@@ -58,33 +78,59 @@ func (q *Queries) GetEntry(ctx context.Context, id int64) (*Entry, error) {
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.TransferID,
+		&i.JournalID,
+		&i.Description,
+		&i.Category,
 	)
 	return &i, err
 }
 
 const listEntries = `-- name: ListEntries :many
 SELECT
-  id, account_id, amount, created_at
+  id, account_id, amount, created_at, transfer_id, journal_id, description, category
 FROM
   entries
 WHERE
   account_id = $1
+  AND
+  (
+    (amount < 0 AND $2::boolean)
+    OR
+    (amount > 0 AND $3::boolean)
+  )
+  AND
+  ($4::timestamptz IS NULL OR created_at >= $4)
+  AND
+  ($5::timestamptz IS NULL OR created_at < $5)
+  AND
+  id > $6
 ORDER BY
   id
 LIMIT
-  $2
-OFFSET
-  $3
+  $7
 `
 
 type ListEntriesParams struct {
-	AccountID int64 `json:"account_id"`
-	Limit     int32 `json:"limit"`
-	Offset    int32 `json:"offset"`
+	AccountID int64      `json:"account_id"`
+	Outgoing  bool       `json:"outgoing"`
+	Incoming  bool       `json:"incoming"`
+	StartTime *time.Time `json:"start_time"`
+	EndTime   *time.Time `json:"end_time"`
+	AfterID   int64      `json:"after_id"`
+	Limit     int32      `json:"limit"`
 }
 
 func (q *Queries) ListEntries(ctx context.Context, arg *ListEntriesParams) ([]*Entry, error) {
-	rows, err := q.db.Query(ctx, listEntries, arg.AccountID, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listEntries,
+		arg.AccountID,
+		arg.Outgoing,
+		arg.Incoming,
+		arg.StartTime,
+		arg.EndTime,
+		arg.AfterID,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -97,6 +143,10 @@ func (q *Queries) ListEntries(ctx context.Context, arg *ListEntriesParams) ([]*E
 			&i.AccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.TransferID,
+			&i.JournalID,
+			&i.Description,
+			&i.Category,
 		); err != nil {
 			return nil, err
 		}
@@ -7,9 +7,14 @@ import (
 )
 
 const (
-	UniqueViolation = "23505"
+	ForeignKeyViolation = "23503"
+	UniqueViolation     = "23505"
+	CheckViolation      = "23514"
 )
 
+// BalanceOverdraftConstraint is the check constraint that keeps the balance of an account within its overdraft limit
+const BalanceOverdraftConstraint = "accounts_balance_overdraft_check"
+
 func ErrorCode(err error) string {
 	var pgErr *pgconn.PgError
 	if errors.As(err, &pgErr) {
@@ -18,3 +23,13 @@ func ErrorCode(err error) string {
 
 	return ""
 }
+
+// ConstraintName returns the name of the constraint that is violated by the error, if any
+func ConstraintName(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.ConstraintName
+	}
+
+	return ""
+}
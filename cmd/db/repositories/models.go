@@ -5,6 +5,7 @@
 package db
 
 import (
+	"kara-bank/utils"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,6 +17,21 @@ type Account struct {
 	Balance   int64     `json:"balance"`
 	Currency  string    `json:"currency"`
 	CreatedAt time.Time `json:"created_at"`
+	// how far the balance may go below zero
+	OverdraftLimit int64 `json:"overdraft_limit"`
+	// sum of the active holds, not available for new transfers
+	HeldAmount int64 `json:"held_amount"`
+	// ledger balance minus the held amount
+	AvailableBalance int64 `json:"available_balance"`
+}
+
+// daily balances of the accounts, computed from the entries
+type BalanceSnapshot struct {
+	AccountID int64 `json:"account_id"`
+	// start of the next day in UTC, the balance is the sum of the entries created before
+	EndTime   time.Time `json:"end_time"`
+	Balance   int64     `json:"balance"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type Entry struct {
@@ -24,6 +40,68 @@ type Entry struct {
 	// can be negative or positive
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"created_at"`
+	// transfer that produced the entry, null for manual journals
+	TransferID *int64 `json:"transfer_id"`
+	// journal that booked the entry
+	JournalID   *int64 `json:"journal_id"`
+	Description string `json:"description"`
+	// e.g. transfer, reversal or the category of a manual journal
+	Category string `json:"category"`
+}
+
+type Hold struct {
+	ID        int64 `json:"id"`
+	AccountID int64 `json:"account_id"`
+	// reserved on the account while the hold is active
+	Amount      int64  `json:"amount"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	// transfer that captured the hold
+	TransferID *int64 `json:"transfer_id"`
+	// the hold is released automatically after this time, null if it does not expire
+	ExpiresAt *time.Time `json:"expires_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	ClosedAt  *time.Time `json:"closed_at"`
+}
+
+type IdempotencyKey struct {
+	Email          string `json:"email"`
+	IdempotencyKey string `json:"idempotency_key"`
+	RequestHash    string `json:"request_hash"`
+	// null while the first request with this key is in progress
+	ResponseBody []byte    `json:"response_body"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+	// lease of the request in progress, another request with the key can take over after it, null once the response is stored
+	LockedUntil *time.Time `json:"locked_until"`
+}
+
+type Journal struct {
+	ID          int64  `json:"id"`
+	Description string `json:"description"`
+	// transfer that is booked by this journal, null for manual journals
+	TransferID *int64    `json:"transfer_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// chart of accounts of the bank
+type LedgerAccount struct {
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Posting struct {
+	ID            int64  `json:"id"`
+	JournalID     int64  `json:"journal_id"`
+	LedgerAccount string `json:"ledger_account"`
+	// customer account of the posting, only for the customer deposits ledger account
+	AccountID *int64 `json:"account_id"`
+	// debit if positive, credit if negative. The postings of a journal sum to zero per currency
+	Amount    int64     `json:"amount"`
+	Currency  string    `json:"currency"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type Session struct {
@@ -37,6 +115,26 @@ type Session struct {
 	CreatedAt    time.Time `json:"created_at"`
 }
 
+type StandingOrder struct {
+	ID            int64      `json:"id"`
+	FromAccountID int64      `json:"from_account_id"`
+	ToAccountID   int64      `json:"to_account_id"`
+	Amount        int64      `json:"amount"`
+	Frequency     string     `json:"frequency"`
+	StartAt       time.Time  `json:"start_at"`
+	EndAt         *time.Time `json:"end_at"`
+	Status        string     `json:"status"`
+	// next attempt, also used for retries of a failed execution. null if the order is not active
+	NextExecutionAt *time.Time `json:"next_execution_at"`
+	// number of scheduled executions that are done, including executions that were skipped after too many failures
+	ExecutionCount int32 `json:"execution_count"`
+	// failed attempts of the current execution
+	FailedAttempts int32     `json:"failed_attempts"`
+	LastError      *string   `json:"last_error"`
+	LastTransferID *int64    `json:"last_transfer_id"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
 type Transfer struct {
 	ID            int64 `json:"id"`
 	FromAccountID int64 `json:"from_account_id"`
@@ -44,6 +142,30 @@ type Transfer struct {
 	// must be positive
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"created_at"`
+	// amount credited to the receiving account in its currency
+	ToAmount int64 `json:"to_amount"`
+	// rate used to convert amount into to_amount, in units of 10^-8
+	ExchangeRate utils.ExchangeRate `json:"exchange_rate"`
+	// transfer that is fully or partially reversed by this transfer
+	ReversalOf *int64 `json:"reversal_of"`
+}
+
+type TransferApproval struct {
+	ID            int64 `json:"id"`
+	FromAccountID int64 `json:"from_account_id"`
+	ToAccountID   int64 `json:"to_account_id"`
+	// held on the sending account until the transfer is approved or rejected
+	Amount    int64  `json:"amount"`
+	Initiator string `json:"initiator"`
+	Status    string `json:"status"`
+	// banker who approved or rejected the transfer, must not be the initiator
+	Reviewer *string `json:"reviewer"`
+	// transfer that was executed after the approval
+	TransferID *int64     `json:"transfer_id"`
+	CreatedAt  time.Time  `json:"created_at"`
+	DecidedAt  *time.Time `json:"decided_at"`
+	// reserves the amount on the sending account until the transfer is approved or rejected
+	HoldID *int64 `json:"hold_id"`
 }
 
 type User struct {
@@ -6,28 +6,75 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
 	AddAccountBalance(ctx context.Context, arg *AddAccountBalanceParams) (*Account, error)
+	AddAccountHeldAmount(ctx context.Context, arg *AddAccountHeldAmountParams) (*Account, error)
+	BlockSession(ctx context.Context, id uuid.UUID) error
+	BlockSessionsByEmail(ctx context.Context, email string) error
+	CloseHold(ctx context.Context, arg *CloseHoldParams) (*Hold, error)
+	CompleteIdempotencyKey(ctx context.Context, arg *CompleteIdempotencyKeyParams) error
 	CreateAccount(ctx context.Context, arg *CreateAccountParams) (*Account, error)
+	CreateBalanceSnapshots(ctx context.Context, arg *CreateBalanceSnapshotsParams) (int64, error)
 	CreateEntry(ctx context.Context, arg *CreateEntryParams) (*Entry, error)
+	CreateHold(ctx context.Context, arg *CreateHoldParams) (*Hold, error)
+	CreateIdempotencyKey(ctx context.Context, arg *CreateIdempotencyKeyParams) (*IdempotencyKey, error)
+	CreateJournal(ctx context.Context, arg *CreateJournalParams) (*Journal, error)
+	CreateLedgerAccount(ctx context.Context, arg *CreateLedgerAccountParams) (*LedgerAccount, error)
+	CreatePosting(ctx context.Context, arg *CreatePostingParams) (*Posting, error)
 	CreateSession(ctx context.Context, arg *CreateSessionParams) (*Session, error)
+	CreateStandingOrder(ctx context.Context, arg *CreateStandingOrderParams) (*StandingOrder, error)
 	CreateTransfer(ctx context.Context, arg *CreateTransferParams) (*Transfer, error)
+	CreateTransferApproval(ctx context.Context, arg *CreateTransferApprovalParams) (*TransferApproval, error)
+	DecideTransferApproval(ctx context.Context, arg *DecideTransferApprovalParams) (*TransferApproval, error)
 	DeleteAccount(ctx context.Context, id int64) error
+	DeleteExpiredIdempotencyKey(ctx context.Context, arg *DeleteExpiredIdempotencyKeyParams) error
+	DeleteIdempotencyKey(ctx context.Context, arg *DeleteIdempotencyKeyParams) error
 	GetAccount(ctx context.Context, id int64) (*Account, error)
 	GetAccountForUpdate(ctx context.Context, id int64) (*Account, error)
+	GetBalanceSnapshotBefore(ctx context.Context, arg *GetBalanceSnapshotBeforeParams) (*BalanceSnapshot, error)
 	GetEntry(ctx context.Context, id int64) (*Entry, error)
+	GetFirstEntryTime(ctx context.Context) (time.Time, error)
+	GetHold(ctx context.Context, id int64) (*Hold, error)
+	GetHoldForUpdate(ctx context.Context, id int64) (*Hold, error)
+	GetIdempotencyKey(ctx context.Context, arg *GetIdempotencyKeyParams) (*IdempotencyKey, error)
+	GetJournal(ctx context.Context, id int64) (*Journal, error)
+	GetLatestBalanceSnapshotTime(ctx context.Context) (time.Time, error)
+	GetReversedAmount(ctx context.Context, transferID int64) (*GetReversedAmountRow, error)
 	GetSessions(ctx context.Context, id uuid.UUID) (*Session, error)
+	GetStandingOrder(ctx context.Context, id int64) (*StandingOrder, error)
 	GetTransfer(ctx context.Context, id int64) (*Transfer, error)
+	GetTransferApproval(ctx context.Context, id int64) (*TransferApproval, error)
+	GetTransferApprovalByHold(ctx context.Context, holdID *int64) (*TransferApproval, error)
+	GetTransferApprovalForUpdate(ctx context.Context, id int64) (*TransferApproval, error)
+	GetTransferForUpdate(ctx context.Context, id int64) (*Transfer, error)
+	GetTrialBalance(ctx context.Context, endTime *time.Time) ([]*GetTrialBalanceRow, error)
 	GetUser(ctx context.Context, email string) (*User, error)
 	ListAccounts(ctx context.Context, arg *ListAccountsParams) ([]*Account, error)
+	ListAccountsByOwner(ctx context.Context, arg *ListAccountsByOwnerParams) ([]*Account, error)
+	ListActiveSessions(ctx context.Context, email string) ([]*Session, error)
+	ListBalanceDrifts(ctx context.Context) ([]*ListBalanceDriftsRow, error)
+	ListDueStandingOrders(ctx context.Context, arg *ListDueStandingOrdersParams) ([]*StandingOrder, error)
 	ListEntries(ctx context.Context, arg *ListEntriesParams) ([]*Entry, error)
+	ListExpiredHolds(ctx context.Context, arg *ListExpiredHoldsParams) ([]*Hold, error)
+	ListHoldsByAccount(ctx context.Context, arg *ListHoldsByAccountParams) ([]*Hold, error)
+	ListLedgerAccounts(ctx context.Context) ([]*LedgerAccount, error)
+	ListOrphanEntries(ctx context.Context) ([]*Entry, error)
+	ListPendingTransferApprovals(ctx context.Context, arg *ListPendingTransferApprovalsParams) ([]*TransferApproval, error)
+	ListPostingsByJournal(ctx context.Context, journalID int64) ([]*Posting, error)
+	ListStandingOrdersByOwner(ctx context.Context, arg *ListStandingOrdersByOwnerParams) ([]*StandingOrder, error)
 	ListTransfers(ctx context.Context, arg *ListTransfersParams) ([]*Transfer, error)
+	ListUnbalancedTransfers(ctx context.Context) ([]*ListUnbalancedTransfersRow, error)
 	RegisterUser(ctx context.Context, arg *RegisterUserParams) (*User, error)
+	SumEntriesUntil(ctx context.Context, arg *SumEntriesUntilParams) (int64, error)
 	UpdateAccount(ctx context.Context, arg *UpdateAccountParams) (*Account, error)
+	UpdateAccountOverdraftLimit(ctx context.Context, arg *UpdateAccountOverdraftLimitParams) (*Account, error)
+	UpdateStandingOrder(ctx context.Context, arg *UpdateStandingOrderParams) (*StandingOrder, error)
+	UpdateStandingOrderExecution(ctx context.Context, arg *UpdateStandingOrderExecutionParams) (*StandingOrder, error)
 }
 
 var _ Querier = (*Queries)(nil)
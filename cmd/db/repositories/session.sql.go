@@ -12,6 +12,34 @@ import (
 	"github.com/google/uuid"
 )
 
+const blockSession = `-- name: BlockSession :exec
+UPDATE
+  sessions
+SET
+  is_blocked = true
+WHERE
+  id = $1
+`
+
+func (q *Queries) BlockSession(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, blockSession, id)
+	return err
+}
+
+const blockSessionsByEmail = `-- name: BlockSessionsByEmail :exec
+UPDATE
+  sessions
+SET
+  is_blocked = true
+WHERE
+  email = $1
+`
+
+func (q *Queries) BlockSessionsByEmail(ctx context.Context, email string) error {
+	_, err := q.db.Exec(ctx, blockSessionsByEmail, email)
+	return err
+}
+
 const createSession = `-- name: CreateSession :one
 INSERT INTO
   sessions (
@@ -90,3 +118,47 @@ func (q *Queries) GetSessions(ctx context.Context, id uuid.UUID) (*Session, erro
 	)
 	return &i, err
 }
+
+const listActiveSessions = `-- name: ListActiveSessions :many
+SELECT
+  id, email, refresh_token, user_agent, client_ip, is_blocked, expires_at, created_at
+FROM
+  sessions
+WHERE
+  email = $1
+  AND
+  is_blocked = false
+  AND
+  expires_at > now()
+ORDER BY
+  created_at DESC
+`
+
+func (q *Queries) ListActiveSessions(ctx context.Context, email string) ([]*Session, error) {
+	rows, err := q.db.Query(ctx, listActiveSessions, email)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*Session
+	for rows.Next() {
+		var i Session
+		if err := rows.Scan(
+			&i.ID,
+			&i.Email,
+			&i.RefreshToken,
+			&i.UserAgent,
+			&i.ClientIp,
+			&i.IsBlocked,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
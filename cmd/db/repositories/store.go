@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -11,6 +12,16 @@ import (
 type Store interface {
 	Querier
 	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
+	ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (TransferTxResult, error)
+	CreateAccountTx(ctx context.Context, arg CreateAccountTxParams) (*Account, error)
+	CreateTransferApprovalTx(ctx context.Context, arg CreateTransferApprovalTxParams) (*TransferApproval, error)
+	ApproveTransferTx(ctx context.Context, arg DecideTransferApprovalTxParams) (ApproveTransferTxResult, error)
+	RejectTransferTx(ctx context.Context, arg DecideTransferApprovalTxParams) (*TransferApproval, error)
+	CreateHoldTx(ctx context.Context, arg CreateHoldParams) (*Hold, error)
+	CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error)
+	ReleaseHoldTx(ctx context.Context, holdID int64) (*Hold, error)
+	ExpireHoldTx(ctx context.Context, holdID int64, now time.Time) (*Hold, error)
+	PostJournalTx(ctx context.Context, arg PostJournalTxParams) (JournalTxResult, error)
 
 	// only for tests!
 	ClearUsersTable() (pgconn.CommandTag, error)
@@ -18,6 +29,12 @@ type Store interface {
 	ClearTransfersTable() (pgconn.CommandTag, error)
 	ClearEntriesTable() (pgconn.CommandTag, error)
 	ClearSessionsTable() (pgconn.CommandTag, error)
+	ClearIdempotencyKeysTable() (pgconn.CommandTag, error)
+	ClearStandingOrdersTable() (pgconn.CommandTag, error)
+	ClearTransferApprovalsTable() (pgconn.CommandTag, error)
+	ClearHoldsTable() (pgconn.CommandTag, error)
+	ClearPostingsTable() (pgconn.CommandTag, error)
+	ClearJournalsTable() (pgconn.CommandTag, error)
 	SetAccountBalance(ctx context.Context, accountId int64, balance int64) (*Account, error)
 }
 
@@ -91,6 +108,48 @@ func (store *SQLStore) ClearSessionsTable() (pgconn.CommandTag, error) {
 	return store.connPool.Exec(context.Background(), query)
 }
 
+func (store *SQLStore) ClearIdempotencyKeysTable() (pgconn.CommandTag, error) {
+	query := `
+		DELETE FROM
+			idempotency_keys`
+	return store.connPool.Exec(context.Background(), query)
+}
+
+func (store *SQLStore) ClearStandingOrdersTable() (pgconn.CommandTag, error) {
+	query := `
+		DELETE FROM
+			standing_orders`
+	return store.connPool.Exec(context.Background(), query)
+}
+
+func (store *SQLStore) ClearTransferApprovalsTable() (pgconn.CommandTag, error) {
+	query := `
+		DELETE FROM
+			transfer_approvals`
+	return store.connPool.Exec(context.Background(), query)
+}
+
+func (store *SQLStore) ClearHoldsTable() (pgconn.CommandTag, error) {
+	query := `
+		DELETE FROM
+			holds`
+	return store.connPool.Exec(context.Background(), query)
+}
+
+func (store *SQLStore) ClearPostingsTable() (pgconn.CommandTag, error) {
+	query := `
+		DELETE FROM
+			postings`
+	return store.connPool.Exec(context.Background(), query)
+}
+
+func (store *SQLStore) ClearJournalsTable() (pgconn.CommandTag, error) {
+	query := `
+		DELETE FROM
+			journals`
+	return store.connPool.Exec(context.Background(), query)
+}
+
 func (store *SQLStore) SetAccountBalance(ctx context.Context, accountId int64, balance int64) (*Account, error) {
 	updateAccountParam := &UpdateAccountParams{
 		ID:      accountId,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -21,12 +22,21 @@ func TestTxTransferSuite(t *testing.T) {
 }
 
 func (suite *TxTransferTestSuite) AfterTest(suiteName string, testName string) {
-	_, err := testStore.ClearTransfersTable()
+	_, err := testStore.ClearHoldsTable()
 	require.NoError(suite.T(), err)
 
 	_, err = testStore.ClearEntriesTable()
 	require.NoError(suite.T(), err)
 
+	_, err = testStore.ClearPostingsTable()
+	require.NoError(suite.T(), err)
+
+	_, err = testStore.ClearJournalsTable()
+	require.NoError(suite.T(), err)
+
+	_, err = testStore.ClearTransfersTable()
+	require.NoError(suite.T(), err)
+
 	_, err = testStore.ClearAccountsTable()
 	require.NoError(suite.T(), err)
 
@@ -117,6 +127,10 @@ func (suite *TxTransferTestSuite) TestTransferTx() {
 		require.Equal(suite.T(), -amount, fromEntry.Amount)
 		require.NotZero(suite.T(), fromEntry.ID)
 		require.NotZero(suite.T(), fromEntry.CreatedAt)
+		require.Equal(suite.T(), &transfer.ID, fromEntry.TransferID)
+		require.NotNil(suite.T(), fromEntry.JournalID)
+		require.Equal(suite.T(), EntryTransfer, fromEntry.Category)
+		require.Equal(suite.T(), fmt.Sprintf("transfer to account %d", account2.ID), fromEntry.Description)
 
 		_, err = testStore.GetEntry(context.Background(), fromEntry.ID)
 		require.NoError(suite.T(), err)
@@ -127,6 +141,9 @@ func (suite *TxTransferTestSuite) TestTransferTx() {
 		require.Equal(suite.T(), amount, toEntry.Amount)
 		require.NotZero(suite.T(), toEntry.ID)
 		require.NotZero(suite.T(), toEntry.CreatedAt)
+		require.Equal(suite.T(), &transfer.ID, toEntry.TransferID)
+		require.Equal(suite.T(), fromEntry.JournalID, toEntry.JournalID)
+		require.Equal(suite.T(), fmt.Sprintf("transfer from account %d", account1.ID), toEntry.Description)
 
 		_, err = testStore.GetEntry(context.Background(), toEntry.ID)
 		require.NoError(suite.T(), err)
@@ -242,3 +259,696 @@ func (suite *TxTransferTestSuite) TestTransferTxDeadlock() {
 	require.Equal(suite.T(), account1.Balance, updatedAccount1.Balance)
 	require.Equal(suite.T(), account2.Balance, updatedAccount2.Balance)
 }
+
+func (suite *TxTransferTestSuite) TestTransferTxInsufficientFunds() {
+	user1 := registerTestUser(suite.T(), &RegisterUserParams{
+		Email:          "Max@Mustermann.de",
+		HashedPassword: "",
+		FirstName:      "Max",
+		LastName:       "Mustermann",
+	})
+	user2 := registerTestUser(suite.T(), &RegisterUserParams{
+		Email:          "Tom@Mustermann.de",
+		HashedPassword: "",
+		FirstName:      "Tom",
+		LastName:       "Mustermann",
+	})
+
+	account1 := createTestAccount(suite.T(), CreateAccountParams{
+		Owner:    user1.Email,
+		Balance:  100,
+		Currency: "EUR",
+	})
+	account2 := createTestAccount(suite.T(), CreateAccountParams{
+		Owner:    user2.Email,
+		Balance:  0,
+		Currency: "EUR",
+	})
+
+	_, err := testStore.TransferTx(suite.ctx, TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        101,
+	})
+	require.ErrorIs(suite.T(), err, ErrInsufficientFunds)
+
+	// nothing of the failed transfer is persisted
+	updatedAccount1, err := testStore.GetAccount(suite.ctx, account1.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), account1.Balance, updatedAccount1.Balance)
+
+	entries, err := testStore.ListEntries(suite.ctx, &ListEntriesParams{
+		AccountID: account1.ID,
+		Outgoing:  true,
+		Incoming:  true,
+		Limit:     10,
+	})
+	require.NoError(suite.T(), err)
+	require.Empty(suite.T(), entries)
+}
+
+func (suite *TxTransferTestSuite) TestTransferTxWithinOverdraftLimit() {
+	user1 := registerTestUser(suite.T(), &RegisterUserParams{
+		Email:          "Max@Mustermann.de",
+		HashedPassword: "",
+		FirstName:      "Max",
+		LastName:       "Mustermann",
+	})
+	user2 := registerTestUser(suite.T(), &RegisterUserParams{
+		Email:          "Tom@Mustermann.de",
+		HashedPassword: "",
+		FirstName:      "Tom",
+		LastName:       "Mustermann",
+	})
+
+	account1 := createTestAccount(suite.T(), CreateAccountParams{
+		Owner:    user1.Email,
+		Balance:  100,
+		Currency: "EUR",
+	})
+	account2 := createTestAccount(suite.T(), CreateAccountParams{
+		Owner:    user2.Email,
+		Balance:  0,
+		Currency: "EUR",
+	})
+
+	account1, err := testStore.UpdateAccountOverdraftLimit(suite.ctx, &UpdateAccountOverdraftLimitParams{
+		ID:             account1.ID,
+		OverdraftLimit: 50,
+	})
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), int64(50), account1.OverdraftLimit)
+
+	result, err := testStore.TransferTx(suite.ctx, TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        150,
+	})
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), int64(-50), result.FromAccount.Balance)
+
+	_, err = testStore.TransferTx(suite.ctx, TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        1,
+	})
+	require.ErrorIs(suite.T(), err, ErrInsufficientFunds)
+}
+
+func (suite *TxTransferTestSuite) TestTransferTxCurrencies() {
+	user1 := registerTestUser(suite.T(), &RegisterUserParams{
+		Email:          "Max@Mustermann.de",
+		HashedPassword: "",
+		FirstName:      "Max",
+		LastName:       "Mustermann",
+	})
+	user2 := registerTestUser(suite.T(), &RegisterUserParams{
+		Email:          "Tom@Mustermann.de",
+		HashedPassword: "",
+		FirstName:      "Tom",
+		LastName:       "Mustermann",
+	})
+
+	account1 := createTestAccount(suite.T(), CreateAccountParams{
+		Owner:    user1.Email,
+		Balance:  100,
+		Currency: "EUR",
+	})
+	account2 := createTestAccount(suite.T(), CreateAccountParams{
+		Owner:    user2.Email,
+		Balance:  0,
+		Currency: "USD",
+	})
+
+	// different currencies without conversion are rejected
+	_, err := testStore.TransferTx(suite.ctx, TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.ErrorIs(suite.T(), err, ErrCurrencyMismatch)
+
+	result, err := testStore.TransferTx(suite.ctx, TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+		ToAmount:      12,
+		ExchangeRate:  120_000_000,
+	})
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), int64(10), result.Transfer.Amount)
+	require.Equal(suite.T(), int64(12), result.Transfer.ToAmount)
+	require.Equal(suite.T(), "1.2", result.Transfer.ExchangeRate.String())
+	require.Equal(suite.T(), int64(-10), result.FromEntry.Amount)
+	require.Equal(suite.T(), int64(12), result.ToEntry.Amount)
+	require.Equal(suite.T(), int64(90), result.FromAccount.Balance)
+	require.Equal(suite.T(), int64(12), result.ToAccount.Balance)
+}
+
+func (suite *TxTransferTestSuite) TestReverseTransferTx() {
+	user1 := registerTestUser(suite.T(), &RegisterUserParams{
+		Email:          "Max@Mustermann.de",
+		HashedPassword: "",
+		FirstName:      "Max",
+		LastName:       "Mustermann",
+	})
+	user2 := registerTestUser(suite.T(), &RegisterUserParams{
+		Email:          "Tom@Mustermann.de",
+		HashedPassword: "",
+		FirstName:      "Tom",
+		LastName:       "Mustermann",
+	})
+
+	account1 := createTestAccount(suite.T(), CreateAccountParams{
+		Owner:    user1.Email,
+		Balance:  100,
+		Currency: "EUR",
+	})
+	account2 := createTestAccount(suite.T(), CreateAccountParams{
+		Owner:    user2.Email,
+		Balance:  0,
+		Currency: "USD",
+	})
+
+	original, err := testStore.TransferTx(suite.ctx, TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+		ToAmount:      12,
+		ExchangeRate:  120_000_000,
+	})
+	require.NoError(suite.T(), err)
+
+	// concurrent partial reversals cannot reverse more than the transfer
+	n := 5
+	errs := make(chan error)
+
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+				TransferID: original.Transfer.ID,
+				Amount:     3,
+			})
+
+			errs <- err
+		}()
+	}
+
+	succeeded := 0
+
+	for i := 0; i < n; i++ {
+		err := <-errs
+
+		if err == nil {
+			succeeded++
+			continue
+		}
+
+		require.ErrorIs(suite.T(), err, ErrInvalidReversalAmount)
+	}
+
+	require.Equal(suite.T(), 3, succeeded)
+
+	// the rest is reversed without an amount
+	result, err := testStore.ReverseTransferTx(suite.ctx, ReverseTransferTxParams{
+		TransferID: original.Transfer.ID,
+	})
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), original.Transfer.ID, *result.Transfer.ReversalOf)
+	require.Equal(suite.T(), account2.ID, result.Transfer.FromAccountID)
+	require.Equal(suite.T(), account1.ID, result.Transfer.ToAccountID)
+	require.Equal(suite.T(), int64(1), result.Transfer.ToAmount)
+	require.Equal(suite.T(), EntryReversal, result.FromEntry.Category)
+	require.Equal(suite.T(), EntryReversal, result.ToEntry.Category)
+
+	// partial reversals add up to the original transfer
+	require.Equal(suite.T(), account1.Balance, result.ToAccount.Balance)
+	require.Equal(suite.T(), account2.Balance, result.FromAccount.Balance)
+
+	_, err = testStore.ReverseTransferTx(suite.ctx, ReverseTransferTxParams{
+		TransferID: original.Transfer.ID,
+	})
+	require.ErrorIs(suite.T(), err, ErrTransferAlreadyReversed)
+
+	_, err = testStore.ReverseTransferTx(suite.ctx, ReverseTransferTxParams{
+		TransferID: result.Transfer.ID,
+	})
+	require.ErrorIs(suite.T(), err, ErrReversalOfReversal)
+}
+
+func (suite *TxTransferTestSuite) TestHoldTx() {
+	user1 := registerTestUser(suite.T(), &RegisterUserParams{
+		Email:          "Max@Mustermann.de",
+		HashedPassword: "",
+		FirstName:      "Max",
+		LastName:       "Mustermann",
+	})
+	user2 := registerTestUser(suite.T(), &RegisterUserParams{
+		Email:          "Tom@Mustermann.de",
+		HashedPassword: "",
+		FirstName:      "Tom",
+		LastName:       "Mustermann",
+	})
+
+	account1 := createTestAccount(suite.T(), CreateAccountParams{
+		Owner:    user1.Email,
+		Balance:  100,
+		Currency: "EUR",
+	})
+	account2 := createTestAccount(suite.T(), CreateAccountParams{
+		Owner:    user2.Email,
+		Balance:  0,
+		Currency: "EUR",
+	})
+
+	// concurrent holds cannot reserve more than the available balance
+	n := 5
+	expiresAt := time.Now().Add(time.Hour)
+	errs := make(chan error)
+	results := make(chan *Hold)
+
+	for i := 0; i < n; i++ {
+		go func() {
+			hold, err := testStore.CreateHoldTx(context.Background(), CreateHoldParams{
+				AccountID:   account1.ID,
+				Amount:      30,
+				Description: fmt.Sprintf("card payment %d", i),
+				ExpiresAt:   &expiresAt,
+			})
+
+			errs <- err
+			results <- hold
+		}()
+	}
+
+	var holds []*Hold
+
+	for i := 0; i < n; i++ {
+		err := <-errs
+		hold := <-results
+
+		if err == nil {
+			require.Equal(suite.T(), HoldActive, hold.Status)
+			holds = append(holds, hold)
+			continue
+		}
+
+		require.ErrorIs(suite.T(), err, ErrInsufficientFunds)
+	}
+
+	require.Len(suite.T(), holds, 3)
+
+	account, err := testStore.GetAccount(suite.ctx, account1.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), int64(100), account.Balance)
+	require.Equal(suite.T(), int64(90), account.HeldAmount)
+	require.Equal(suite.T(), int64(10), account.AvailableBalance)
+
+	// held money is not available for transfers
+	_, err = testStore.TransferTx(suite.ctx, TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        11,
+	})
+	require.ErrorIs(suite.T(), err, ErrInsufficientFunds)
+
+	// a partial capture releases the rest of the hold
+	captured, err := testStore.CaptureHoldTx(suite.ctx, CaptureHoldTxParams{
+		HoldID:      holds[0].ID,
+		ToAccountID: account2.ID,
+		Amount:      20,
+	})
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), HoldCaptured, captured.Hold.Status)
+	require.Equal(suite.T(), captured.Transfer.ID, *captured.Hold.TransferID)
+	require.Equal(suite.T(), int64(80), captured.FromAccount.Balance)
+	require.Equal(suite.T(), int64(60), captured.FromAccount.HeldAmount)
+	require.Equal(suite.T(), int64(20), captured.FromAccount.AvailableBalance)
+	require.Equal(suite.T(), int64(20), captured.ToAccount.Balance)
+
+	_, err = testStore.CaptureHoldTx(suite.ctx, CaptureHoldTxParams{
+		HoldID:      holds[0].ID,
+		ToAccountID: account2.ID,
+		Amount:      10,
+	})
+	require.ErrorIs(suite.T(), err, ErrHoldClosed)
+
+	_, err = testStore.CaptureHoldTx(suite.ctx, CaptureHoldTxParams{
+		HoldID:      holds[1].ID,
+		ToAccountID: account2.ID,
+		Amount:      31,
+	})
+	require.ErrorIs(suite.T(), err, ErrInvalidCaptureAmount)
+
+	released, err := testStore.ReleaseHoldTx(suite.ctx, holds[1].ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), HoldReleased, released.Status)
+	require.NotNil(suite.T(), released.ClosedAt)
+
+	// holds are only expired after their expiry time
+	expired, err := testStore.ExpireHoldTx(suite.ctx, holds[2].ID, time.Now())
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), HoldActive, expired.Status)
+
+	expired, err = testStore.ExpireHoldTx(suite.ctx, holds[2].ID, expiresAt)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), HoldExpired, expired.Status)
+
+	account, err = testStore.GetAccount(suite.ctx, account1.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), int64(80), account.Balance)
+	require.Equal(suite.T(), int64(0), account.HeldAmount)
+	require.Equal(suite.T(), int64(80), account.AvailableBalance)
+}
+
+func (suite *TxTransferTestSuite) TestJournalTx() {
+	user1 := registerTestUser(suite.T(), &RegisterUserParams{
+		Email:          "Max@Mustermann.de",
+		HashedPassword: "",
+		FirstName:      "Max",
+		LastName:       "Mustermann",
+	})
+	user2 := registerTestUser(suite.T(), &RegisterUserParams{
+		Email:          "Tom@Mustermann.de",
+		HashedPassword: "",
+		FirstName:      "Tom",
+		LastName:       "Mustermann",
+	})
+
+	account1 := createTestAccount(suite.T(), CreateAccountParams{
+		Owner:    user1.Email,
+		Balance:  100,
+		Currency: "EUR",
+	})
+	account2 := createTestAccount(suite.T(), CreateAccountParams{
+		Owner:    user2.Email,
+		Balance:  0,
+		Currency: "USD",
+	})
+
+	// a transfer between currencies is balanced per currency by the foreign exchange clearing account
+	_, err := testStore.TransferTx(suite.ctx, TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+		ToAmount:      11,
+		ExchangeRate:  110_000_000,
+	})
+	require.NoError(suite.T(), err)
+
+	trialBalance, err := testStore.GetTrialBalance(suite.ctx, nil)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), []*GetTrialBalanceRow{
+		{LedgerAccount: LedgerForeignExchange, Name: "Foreign exchange clearing", Type: "asset", Currency: "EUR", Debit: 0, Credit: 10},
+		{LedgerAccount: LedgerForeignExchange, Name: "Foreign exchange clearing", Type: "asset", Currency: "USD", Debit: 11, Credit: 0},
+		{LedgerAccount: LedgerCustomerDeposits, Name: "Customer deposits", Type: "liability", Currency: "EUR", Debit: 10, Credit: 0},
+		{LedgerAccount: LedgerCustomerDeposits, Name: "Customer deposits", Type: "liability", Currency: "USD", Debit: 0, Credit: 11},
+	}, trialBalance)
+
+	// a fee debits the customer deposit and credits the fee income
+	fee, err := testStore.PostJournalTx(suite.ctx, PostJournalTxParams{
+		Description: "account fee",
+		Postings: []JournalPostingParams{
+			{LedgerAccount: LedgerCustomerDeposits, AccountID: &account1.ID, Amount: 5, Currency: "EUR"},
+			{LedgerAccount: LedgerFeeIncome, Amount: -5, Currency: "EUR"},
+		},
+	})
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "account fee", fee.Journal.Description)
+	require.Nil(suite.T(), fee.Journal.TransferID)
+	require.Len(suite.T(), fee.Postings, 2)
+
+	account, err := testStore.GetAccount(suite.ctx, account1.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), int64(85), account.Balance)
+
+	entries, err := testStore.ListEntries(suite.ctx, &ListEntriesParams{
+		AccountID: account1.ID,
+		Outgoing:  true,
+		Limit:     5,
+	})
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), entries, 2)
+	require.Equal(suite.T(), int64(-5), entries[1].Amount)
+	require.Equal(suite.T(), &fee.Journal.ID, entries[1].JournalID)
+	require.Nil(suite.T(), entries[1].TransferID)
+	require.Equal(suite.T(), "account fee", entries[1].Description)
+	require.Equal(suite.T(), EntryJournal, entries[1].Category)
+
+	invalidJournals := map[error][]JournalPostingParams{
+		ErrUnbalancedJournal: {
+			{LedgerAccount: LedgerCustomerDeposits, AccountID: &account1.ID, Amount: 5, Currency: "EUR"},
+			{LedgerAccount: LedgerFeeIncome, Amount: -4, Currency: "EUR"},
+		},
+		ErrInvalidPosting: {
+			{LedgerAccount: LedgerCustomerDeposits, AccountID: &account2.ID, Amount: 5, Currency: "EUR"},
+			{LedgerAccount: LedgerFeeIncome, Amount: -5, Currency: "EUR"},
+		},
+		ErrInsufficientFunds: {
+			{LedgerAccount: LedgerCustomerDeposits, AccountID: &account1.ID, Amount: 86, Currency: "EUR"},
+			{LedgerAccount: LedgerFeeIncome, Amount: -86, Currency: "EUR"},
+		},
+	}
+
+	for expectedErr, postings := range invalidJournals {
+		_, err = testStore.PostJournalTx(suite.ctx, PostJournalTxParams{
+			Description: "invalid journal",
+			Postings:    postings,
+		})
+		require.ErrorIs(suite.T(), err, expectedErr)
+	}
+
+	// the database rejects unbalanced journals that bypass PostJournalTx
+	err = testStore.(*SQLStore).execTx(suite.ctx, func(q *Queries) error {
+		journal, err := q.CreateJournal(suite.ctx, &CreateJournalParams{Description: "unbalanced"})
+
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreatePosting(suite.ctx, &CreatePostingParams{
+			JournalID:     journal.ID,
+			LedgerAccount: LedgerCash,
+			Amount:        5,
+			Currency:      "EUR",
+		})
+
+		return err
+	})
+	require.ErrorContains(suite.T(), err, "is not balanced")
+}
+
+func (suite *TxTransferTestSuite) TestReconciliationQueries() {
+	user := registerTestUser(suite.T(), &RegisterUserParams{
+		Email:          "Max@Mustermann.de",
+		HashedPassword: "",
+		FirstName:      "Max",
+		LastName:       "Mustermann",
+	})
+
+	account1 := createTestAccount(suite.T(), CreateAccountParams{
+		Owner:    user.Email,
+		Balance:  0,
+		Currency: "EUR",
+	})
+	account2 := createTestAccount(suite.T(), CreateAccountParams{
+		Owner:    user.Email,
+		Balance:  0,
+		Currency: "EUR",
+	})
+
+	// money paid in and transferred by the app is reconciled
+	_, err := testStore.PostJournalTx(suite.ctx, PostJournalTxParams{
+		Description: "cash deposit",
+		Postings: []JournalPostingParams{
+			{LedgerAccount: LedgerCash, Amount: 100, Currency: "EUR"},
+			{LedgerAccount: LedgerCustomerDeposits, AccountID: &account1.ID, Amount: -100, Currency: "EUR"},
+		},
+	})
+	require.NoError(suite.T(), err)
+
+	result, err := testStore.TransferTx(suite.ctx, TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        30,
+	})
+	require.NoError(suite.T(), err)
+
+	// a transfer to the same account has both entries on that account
+	_, err = testStore.TransferTx(suite.ctx, TransferTxParams{
+		FromAccountID: account2.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(suite.T(), err)
+
+	balanceDrifts, err := testStore.ListBalanceDrifts(suite.ctx)
+	require.NoError(suite.T(), err)
+	require.Empty(suite.T(), balanceDrifts)
+
+	unbalancedTransfers, err := testStore.ListUnbalancedTransfers(suite.ctx)
+	require.NoError(suite.T(), err)
+	require.Empty(suite.T(), unbalancedTransfers)
+
+	orphanEntries, err := testStore.ListOrphanEntries(suite.ctx)
+	require.NoError(suite.T(), err)
+	require.Empty(suite.T(), orphanEntries)
+
+	// balances changed outside of a transaction drift from their entries and postings
+	_, err = testStore.SetAccountBalance(suite.ctx, account2.ID, 50)
+	require.NoError(suite.T(), err)
+
+	// entries without a transfer or journal are orphans
+	orphan, err := testStore.CreateEntry(suite.ctx, &CreateEntryParams{
+		AccountID: account1.ID,
+		Amount:    5,
+	})
+	require.NoError(suite.T(), err)
+
+	// an additional entry of the transfer unbalances it
+	_, err = testStore.CreateEntry(suite.ctx, &CreateEntryParams{
+		AccountID:  account1.ID,
+		Amount:     -5,
+		TransferID: &result.Transfer.ID,
+	})
+	require.NoError(suite.T(), err)
+
+	balanceDrifts, err = testStore.ListBalanceDrifts(suite.ctx)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), []*ListBalanceDriftsRow{
+		{AccountID: account2.ID, Balance: 50, EntriesTotal: 30, PostingsTotal: 30},
+	}, balanceDrifts)
+
+	orphanEntries, err = testStore.ListOrphanEntries(suite.ctx)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), orphanEntries, 1)
+	require.Equal(suite.T(), orphan.ID, orphanEntries[0].ID)
+
+	unbalancedTransfers, err = testStore.ListUnbalancedTransfers(suite.ctx)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), []*ListUnbalancedTransfersRow{
+		{
+			TransferID:       result.Transfer.ID,
+			FromAccountID:    account1.ID,
+			ToAccountID:      account2.ID,
+			Amount:           30,
+			ToAmount:         30,
+			FromEntriesTotal: -35,
+			ToEntriesTotal:   30,
+			EntryCount:       3,
+		},
+	}, unbalancedTransfers)
+}
+
+func (suite *TxTransferTestSuite) TestBalanceSnapshotQueries() {
+	user := registerTestUser(suite.T(), &RegisterUserParams{
+		Email:          "Max@Mustermann.de",
+		HashedPassword: "",
+		FirstName:      "Max",
+		LastName:       "Mustermann",
+	})
+
+	account1 := createTestAccount(suite.T(), CreateAccountParams{
+		Owner:    user.Email,
+		Balance:  0,
+		Currency: "EUR",
+	})
+	account2 := createTestAccount(suite.T(), CreateAccountParams{
+		Owner:    user.Email,
+		Balance:  0,
+		Currency: "EUR",
+	})
+
+	_, err := testStore.PostJournalTx(suite.ctx, PostJournalTxParams{
+		Description: "cash deposit",
+		Postings: []JournalPostingParams{
+			{LedgerAccount: LedgerCash, Amount: 100, Currency: "EUR"},
+			{LedgerAccount: LedgerCustomerDeposits, AccountID: &account1.ID, Amount: -100, Currency: "EUR"},
+		},
+	})
+	require.NoError(suite.T(), err)
+
+	_, err = testStore.TransferTx(suite.ctx, TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        30,
+	})
+	require.NoError(suite.T(), err)
+
+	_, err = testStore.GetLatestBalanceSnapshotTime(suite.ctx)
+	require.Error(suite.T(), err)
+
+	// the first snapshot sums all entries created before its end time
+	endTime := time.Now().UTC().Truncate(time.Hour).Add(time.Hour)
+
+	count, err := testStore.CreateBalanceSnapshots(suite.ctx, &CreateBalanceSnapshotsParams{
+		EndTime:         endTime,
+		PreviousEndTime: endTime.AddDate(0, 0, -1),
+	})
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), int64(2), count)
+
+	// existing snapshots are not written again
+	count, err = testStore.CreateBalanceSnapshots(suite.ctx, &CreateBalanceSnapshotsParams{
+		EndTime:         endTime,
+		PreviousEndTime: endTime.AddDate(0, 0, -1),
+	})
+	require.NoError(suite.T(), err)
+	require.Zero(suite.T(), count)
+
+	latest, err := testStore.GetLatestBalanceSnapshotTime(suite.ctx)
+	require.NoError(suite.T(), err)
+	require.WithinDuration(suite.T(), endTime, latest, 0)
+
+	// the next snapshot builds on the previous one
+	nextEndTime := endTime.AddDate(0, 0, 1)
+
+	count, err = testStore.CreateBalanceSnapshots(suite.ctx, &CreateBalanceSnapshotsParams{
+		EndTime:         nextEndTime,
+		PreviousEndTime: endTime,
+	})
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), int64(2), count)
+
+	snapshot, err := testStore.GetBalanceSnapshotBefore(suite.ctx, &GetBalanceSnapshotBeforeParams{
+		AccountID: account1.ID,
+		At:        nextEndTime.Add(time.Minute),
+	})
+	require.NoError(suite.T(), err)
+	require.WithinDuration(suite.T(), nextEndTime, snapshot.EndTime, 0)
+	require.Equal(suite.T(), int64(70), snapshot.Balance)
+
+	snapshot, err = testStore.GetBalanceSnapshotBefore(suite.ctx, &GetBalanceSnapshotBeforeParams{
+		AccountID: account2.ID,
+		At:        endTime,
+	})
+	require.NoError(suite.T(), err)
+	require.WithinDuration(suite.T(), endTime, snapshot.EndTime, 0)
+	require.Equal(suite.T(), int64(30), snapshot.Balance)
+
+	_, err = testStore.GetBalanceSnapshotBefore(suite.ctx, &GetBalanceSnapshotBeforeParams{
+		AccountID: account2.ID,
+		At:        endTime.Add(-time.Minute),
+	})
+	require.Error(suite.T(), err)
+
+	// entries are summed from the end of a snapshot or from the start if no start time is given
+	total, err := testStore.SumEntriesUntil(suite.ctx, &SumEntriesUntilParams{
+		AccountID: account1.ID,
+		At:        time.Now(),
+	})
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), int64(70), total)
+
+	total, err = testStore.SumEntriesUntil(suite.ctx, &SumEntriesUntilParams{
+		AccountID: account1.ID,
+		StartTime: &endTime,
+		At:        nextEndTime,
+	})
+	require.NoError(suite.T(), err)
+	require.Zero(suite.T(), total)
+
+	firstEntry, err := testStore.GetFirstEntryTime(suite.ctx)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), firstEntry.Before(endTime))
+}
@@ -7,6 +7,8 @@ package db
 
 import (
 	"context"
+	"kara-bank/utils"
+	"time"
 )
 
 const createTransfer = `-- name: CreateTransfer :one
@@ -14,23 +16,36 @@ INSERT INTO
   transfers (
     from_account_id,
     to_account_id,
-    amount
+    amount,
+    to_amount,
+    exchange_rate,
+    reversal_of
   )
 VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4, $5, $6
 )
 RETURNING
-  id, from_account_id, to_account_id, amount, created_at
+  id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, reversal_of
 `
 
 type CreateTransferParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	FromAccountID int64              `json:"from_account_id"`
+	ToAccountID   int64              `json:"to_account_id"`
+	Amount        int64              `json:"amount"`
+	ToAmount      int64              `json:"to_amount"`
+	ExchangeRate  utils.ExchangeRate `json:"exchange_rate"`
+	ReversalOf    *int64             `json:"reversal_of"`
 }
 
 func (q *Queries) CreateTransfer(ctx context.Context, arg *CreateTransferParams) (*Transfer, error) {
-	row := q.db.QueryRow(ctx, createTransfer, arg.FromAccountID, arg.ToAccountID, arg.Amount)
+	row := q.db.QueryRow(ctx, createTransfer,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.ToAmount,
+		arg.ExchangeRate,
+		arg.ReversalOf,
+	)
 	var i Transfer
 	err := row.Scan(
 		&i.ID,
@@ -38,13 +53,38 @@ func (q *Queries) CreateTransfer(ctx context.Context, arg *CreateTransferParams)
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.ToAmount,
+		&i.ExchangeRate,
+		&i.ReversalOf,
 	)
 	return &i, err
 }
 
+const getReversedAmount = `-- name: GetReversedAmount :one
+SELECT
+  COALESCE(SUM(amount), 0)::bigint AS amount,
+  COALESCE(SUM(to_amount), 0)::bigint AS to_amount
+FROM
+  transfers
+WHERE
+  reversal_of = $1::bigint
+`
+
+type GetReversedAmountRow struct {
+	Amount   int64 `json:"amount"`
+	ToAmount int64 `json:"to_amount"`
+}
+
+func (q *Queries) GetReversedAmount(ctx context.Context, transferID int64) (*GetReversedAmountRow, error) {
+	row := q.db.QueryRow(ctx, getReversedAmount, transferID)
+	var i GetReversedAmountRow
+	err := row.Scan(&i.Amount, &i.ToAmount)
+	return &i, err
+}
+
 const getTransfer = `-- name: GetTransfer :one
 SELECT
-  id, from_account_id, to_account_id, amount, created_at
+  id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, reversal_of
 FROM
   transfers
 WHERE
@@ -61,40 +101,82 @@ func (q *Queries) GetTransfer(ctx context.Context, id int64) (*Transfer, error)
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.ToAmount,
+		&i.ExchangeRate,
+		&i.ReversalOf,
+	)
+	return &i, err
+}
+
+const getTransferForUpdate = `-- name: GetTransferForUpdate :one
+SELECT
+  id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, reversal_of
+FROM
+  transfers
+WHERE
+  id = $1
+LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetTransferForUpdate(ctx context.Context, id int64) (*Transfer, error) {
+	row := q.db.QueryRow(ctx, getTransferForUpdate, id)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.ToAmount,
+		&i.ExchangeRate,
+		&i.ReversalOf,
 	)
 	return &i, err
 }
 
 const listTransfers = `-- name: ListTransfers :many
 SELECT
-  id, from_account_id, to_account_id, amount, created_at
+  id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, reversal_of
 FROM
   transfers
-WHERE 
-  from_account_id = $1
-  OR
-  to_account_id = $2
+WHERE
+  (
+    (from_account_id = $1 AND $2::boolean)
+    OR
+    (to_account_id = $1 AND $3::boolean)
+  )
+  AND
+  ($4::timestamptz IS NULL OR created_at >= $4)
+  AND
+  ($5::timestamptz IS NULL OR created_at < $5)
+  AND
+  id > $6
 ORDER BY
   id
 LIMIT
-  $3
-OFFSET
-  $4
+  $7
 `
 
 type ListTransfersParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Limit         int32 `json:"limit"`
-	Offset        int32 `json:"offset"`
+	AccountID int64      `json:"account_id"`
+	Outgoing  bool       `json:"outgoing"`
+	Incoming  bool       `json:"incoming"`
+	StartTime *time.Time `json:"start_time"`
+	EndTime   *time.Time `json:"end_time"`
+	AfterID   int64      `json:"after_id"`
+	Limit     int32      `json:"limit"`
 }
 
 func (q *Queries) ListTransfers(ctx context.Context, arg *ListTransfersParams) ([]*Transfer, error) {
 	rows, err := q.db.Query(ctx, listTransfers,
-		arg.FromAccountID,
-		arg.ToAccountID,
+		arg.AccountID,
+		arg.Outgoing,
+		arg.Incoming,
+		arg.StartTime,
+		arg.EndTime,
+		arg.AfterID,
 		arg.Limit,
-		arg.Offset,
 	)
 	if err != nil {
 		return nil, err
@@ -109,6 +191,9 @@ func (q *Queries) ListTransfers(ctx context.Context, arg *ListTransfersParams) (
 			&i.ToAccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.ToAmount,
+			&i.ExchangeRate,
+			&i.ReversalOf,
 		); err != nil {
 			return nil, err
 		}
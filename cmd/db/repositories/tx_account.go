@@ -0,0 +1,31 @@
+package db
+
+import "context"
+
+type CreateAccountTxParams struct {
+	CreateAccountParams
+	// optional, runs within the transaction after the account was created. An error rolls back the creation
+	AfterCreate func(q *Queries, account *Account) error `json:"-"`
+}
+
+// CreateAccountTx creates an account within a database transaction, so that AfterCreate can store data together with it
+func (store *SQLStore) CreateAccountTx(ctx context.Context, arg CreateAccountTxParams) (*Account, error) {
+	var account *Account
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		account, err = q.CreateAccount(ctx, &arg.CreateAccountParams)
+
+		if err != nil {
+			return err
+		}
+
+		if arg.AfterCreate != nil {
+			return arg.AfterCreate(q, account)
+		}
+
+		return nil
+	})
+
+	return account, err
+}
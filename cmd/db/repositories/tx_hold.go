@@ -0,0 +1,227 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"kara-bank/utils"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// states of a hold
+const (
+	HoldActive   = "active"
+	HoldCaptured = "captured"
+	HoldReleased = "released"
+	HoldExpired  = "expired"
+)
+
+// ErrHoldClosed is returned if a hold was already captured, released or expired
+var ErrHoldClosed = errors.New("hold is already captured, released or expired")
+
+// ErrHoldOfTransferApproval is returned by CaptureHoldTx and ReleaseHoldTx for holds of pending transfers, they are
+// captured or released by approving or rejecting the transfer
+var ErrHoldOfTransferApproval = errors.New("hold belongs to a pending transfer, approve or reject the transfer instead")
+
+// ErrInvalidCaptureAmount is returned by CaptureHoldTx if the amount exceeds the held amount
+var ErrInvalidCaptureAmount = errors.New("captured amount exceeds the held amount")
+
+type CaptureHoldTxParams struct {
+	HoldID      int64 `json:"hold_id"`
+	ToAccountID int64 `json:"to_account_id"`
+	// debited from the account of the hold, at most the held amount. The rest of the hold is released
+	Amount int64 `json:"amount"`
+	// credited to the receiving account, only used if the accounts have different currencies
+	ToAmount     int64              `json:"to_amount"`
+	ExchangeRate utils.ExchangeRate `json:"exchange_rate"`
+}
+
+type CaptureHoldTxResult struct {
+	Hold *Hold `json:"hold"`
+	TransferTxResult
+}
+
+// CreateHoldTx reserves the amount on the account. The held amount is not available for transfers until the hold
+// is captured, released or expires
+func (store *SQLStore) CreateHoldTx(ctx context.Context, arg CreateHoldParams) (*Hold, error) {
+	var hold *Hold
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		hold, err = placeHold(ctx, q, arg)
+		return err
+	})
+
+	return hold, err
+}
+
+// CaptureHoldTx transfers the captured amount from the account of the hold and releases the rest of the hold
+func (store *SQLStore) CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error) {
+	var result CaptureHoldTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		hold, err := lockActiveHold(ctx, q, arg.HoldID)
+
+		if err != nil {
+			return err
+		}
+
+		err = checkNoTransferApproval(ctx, q, hold)
+
+		if err != nil {
+			return err
+		}
+
+		result.Hold, result.TransferTxResult, err = captureHold(ctx, q, hold, TransferTxParams{
+			FromAccountID: hold.AccountID,
+			ToAccountID:   arg.ToAccountID,
+			Amount:        arg.Amount,
+			ToAmount:      arg.ToAmount,
+			ExchangeRate:  arg.ExchangeRate,
+		})
+
+		return err
+	})
+
+	return result, err
+}
+
+// ReleaseHoldTx makes the held amount available again without transferring it
+func (store *SQLStore) ReleaseHoldTx(ctx context.Context, holdID int64) (*Hold, error) {
+	var result *Hold
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		hold, err := lockActiveHold(ctx, q, holdID)
+
+		if err != nil {
+			return err
+		}
+
+		err = checkNoTransferApproval(ctx, q, hold)
+
+		if err != nil {
+			return err
+		}
+
+		result, err = releaseHold(ctx, q, hold, HoldReleased)
+		return err
+	})
+
+	return result, err
+}
+
+// ExpireHoldTx releases a hold whose expiry time has passed
+func (store *SQLStore) ExpireHoldTx(ctx context.Context, holdID int64, now time.Time) (*Hold, error) {
+	var result *Hold
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		hold, err := lockActiveHold(ctx, q, holdID)
+
+		if err != nil {
+			return err
+		}
+
+		if hold.ExpiresAt == nil || hold.ExpiresAt.After(now) {
+			result = hold
+			return nil
+		}
+
+		result, err = releaseHold(ctx, q, hold, HoldExpired)
+		return err
+	})
+
+	return result, err
+}
+
+// placeHold runs the steps of CreateHoldTx within the given transaction
+func placeHold(ctx context.Context, q *Queries, arg CreateHoldParams) (*Hold, error) {
+	account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if account.AvailableBalance-arg.Amount < -account.OverdraftLimit {
+		return nil, ErrInsufficientFunds
+	}
+
+	_, err = q.AddAccountHeldAmount(ctx, &AddAccountHeldAmountParams{
+		ID:     account.ID,
+		Amount: arg.Amount,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return q.CreateHold(ctx, &arg)
+}
+
+// lockActiveHold locks the hold for the rest of the transaction, so that it is captured, released or expired only once
+func lockActiveHold(ctx context.Context, q *Queries, id int64) (*Hold, error) {
+	hold, err := q.GetHoldForUpdate(ctx, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if hold.Status != HoldActive {
+		return nil, ErrHoldClosed
+	}
+
+	return hold, nil
+}
+
+// checkNoTransferApproval rejects holds of transfer approvals, they are decided with the approval
+func checkNoTransferApproval(ctx context.Context, q *Queries, hold *Hold) error {
+	_, err := q.GetTransferApprovalByHold(ctx, &hold.ID)
+
+	if err == nil {
+		return ErrHoldOfTransferApproval
+	}
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+
+	return err
+}
+
+// captureHold transfers the amount from the account of the locked hold and releases the whole hold with the transfer
+func captureHold(ctx context.Context, q *Queries, hold *Hold, arg TransferTxParams) (*Hold, TransferTxResult, error) {
+	if arg.Amount <= 0 || arg.Amount > hold.Amount {
+		return nil, TransferTxResult{}, ErrInvalidCaptureAmount
+	}
+
+	result, err := transfer(ctx, q, arg, transferOptions{releaseHold: hold.Amount})
+
+	if err != nil {
+		return nil, result, err
+	}
+
+	captured, err := q.CloseHold(ctx, &CloseHoldParams{
+		ID:         hold.ID,
+		Status:     HoldCaptured,
+		TransferID: &result.Transfer.ID,
+	})
+
+	return captured, result, err
+}
+
+// releaseHold closes the locked hold with the given status and makes the held amount available again
+func releaseHold(ctx context.Context, q *Queries, hold *Hold, status string) (*Hold, error) {
+	_, err := q.AddAccountHeldAmount(ctx, &AddAccountHeldAmountParams{
+		ID:     hold.AccountID,
+		Amount: -hold.Amount,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return q.CloseHold(ctx, &CloseHoldParams{
+		ID:     hold.ID,
+		Status: status,
+	})
+}
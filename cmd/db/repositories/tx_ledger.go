@@ -0,0 +1,249 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// codes of the ledger accounts in the chart of accounts that are booked by the app
+const (
+	LedgerCash             = "1000"
+	LedgerForeignExchange  = "1500"
+	LedgerSuspense         = "1900"
+	LedgerCustomerDeposits = "2000"
+	LedgerEquity           = "3000"
+	LedgerFeeIncome        = "4000"
+	LedgerInterestExpense  = "5000"
+)
+
+// ErrUnbalancedJournal is returned if the postings of a journal do not sum to zero per currency
+var ErrUnbalancedJournal = errors.New("postings of a journal must sum to zero per currency")
+
+// ErrInvalidPosting is returned by PostJournalTx for postings that do not fit the customer deposits ledger account
+var ErrInvalidPosting = errors.New("customer deposits must be posted on a customer account in the currency of the account")
+
+type JournalPostingParams struct {
+	LedgerAccount string `json:"ledger_account"`
+	// customer account, only for the customer deposits ledger account
+	AccountID *int64 `json:"account_id"`
+	// debit if positive, credit if negative
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+type PostJournalTxParams struct {
+	Description string `json:"description"`
+	// category of the entries on customer accounts, EntryJournal if empty
+	Category string                 `json:"category"`
+	Postings []JournalPostingParams `json:"postings"`
+}
+
+type JournalTxResult struct {
+	Journal  *Journal   `json:"journal"`
+	Postings []*Posting `json:"postings"`
+}
+
+// PostJournalTx books a manual journal, e.g. fees, interest or cash movements. Customer accounts are a sub-ledger of the
+// customer deposits ledger account, so postings on them change the balance of the account and add an account entry.
+// A debit takes money from the customer and must not take the available balance below the overdraft limit.
+func (store *SQLStore) PostJournalTx(ctx context.Context, arg PostJournalTxParams) (JournalTxResult, error) {
+	var result JournalTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// balance changes per customer account, a credit to the deposits increases the balance
+		changes := make(map[int64]int64)
+
+		for _, posting := range arg.Postings {
+			if (posting.LedgerAccount == LedgerCustomerDeposits) != (posting.AccountID != nil) {
+				return ErrInvalidPosting
+			}
+
+			if posting.AccountID != nil {
+				changes[*posting.AccountID] -= posting.Amount
+			}
+		}
+
+		accountIDs := make([]int64, 0, len(changes))
+
+		for accountID := range changes {
+			accountIDs = append(accountIDs, accountID)
+		}
+
+		// lock the accounts in the order of their IDs like transfers to prevent deadlocks
+		sort.Slice(accountIDs, func(i, j int) bool { return accountIDs[i] < accountIDs[j] })
+		currencies := make(map[int64]string)
+
+		for _, accountID := range accountIDs {
+			account, err := q.GetAccountForUpdate(ctx, accountID)
+
+			if err != nil {
+				return err
+			}
+
+			if changes[accountID] < 0 && account.AvailableBalance+changes[accountID] < -account.OverdraftLimit {
+				return ErrInsufficientFunds
+			}
+
+			currencies[accountID] = account.Currency
+		}
+
+		for _, posting := range arg.Postings {
+			if posting.AccountID != nil && currencies[*posting.AccountID] != posting.Currency {
+				return ErrInvalidPosting
+			}
+		}
+
+		var err error
+		result, err = postJournal(ctx, q, arg.Description, nil, arg.Postings)
+
+		if err != nil {
+			return err
+		}
+
+		category := arg.Category
+
+		if category == "" {
+			category = EntryJournal
+		}
+
+		for _, accountID := range accountIDs {
+			if changes[accountID] == 0 {
+				continue
+			}
+
+			_, err = q.AddAccountBalance(ctx, &AddAccountBalanceParams{
+				ID:     accountID,
+				Amount: changes[accountID],
+			})
+
+			if err != nil {
+				return err
+			}
+
+			_, err = q.CreateEntry(ctx, &CreateEntryParams{
+				AccountID:   accountID,
+				Amount:      changes[accountID],
+				JournalID:   &result.Journal.ID,
+				Description: arg.Description,
+				Category:    category,
+			})
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// postJournal creates a journal with its postings within the given transaction. The database checks again that the
+// journal is balanced when the transaction commits
+func postJournal(ctx context.Context, q *Queries, description string, transferID *int64, postings []JournalPostingParams) (JournalTxResult, error) {
+	var result JournalTxResult
+
+	if !balanced(postings) {
+		return result, ErrUnbalancedJournal
+	}
+
+	journal, err := q.CreateJournal(ctx, &CreateJournalParams{
+		Description: description,
+		TransferID:  transferID,
+	})
+
+	if err != nil {
+		return result, err
+	}
+
+	result.Journal = journal
+
+	for _, posting := range postings {
+		created, err := q.CreatePosting(ctx, &CreatePostingParams{
+			JournalID:     journal.ID,
+			LedgerAccount: posting.LedgerAccount,
+			AccountID:     posting.AccountID,
+			Amount:        posting.Amount,
+			Currency:      posting.Currency,
+		})
+
+		if err != nil {
+			return result, err
+		}
+
+		result.Postings = append(result.Postings, created)
+	}
+
+	return result, nil
+}
+
+// postTransferJournal books a transfer from the deposit of the sender to the deposit of the receiver. Transfers between
+// currencies are balanced per currency by the foreign exchange clearing account
+func postTransferJournal(ctx context.Context, q *Queries, transfer *Transfer, fromAccount *Account, toAccount *Account) (*Journal, error) {
+	description := fmt.Sprintf("transfer %d", transfer.ID)
+
+	if transfer.ReversalOf != nil {
+		description = fmt.Sprintf("reversal of transfer %d", *transfer.ReversalOf)
+	}
+
+	postings := []JournalPostingParams{
+		{
+			LedgerAccount: LedgerCustomerDeposits,
+			AccountID:     &fromAccount.ID,
+			Amount:        transfer.Amount,
+			Currency:      fromAccount.Currency,
+		},
+		{
+			LedgerAccount: LedgerCustomerDeposits,
+			AccountID:     &toAccount.ID,
+			Amount:        -transfer.ToAmount,
+			Currency:      toAccount.Currency,
+		},
+	}
+
+	if fromAccount.Currency != toAccount.Currency {
+		postings = append(postings,
+			JournalPostingParams{
+				LedgerAccount: LedgerForeignExchange,
+				Amount:        -transfer.Amount,
+				Currency:      fromAccount.Currency,
+			},
+			JournalPostingParams{
+				LedgerAccount: LedgerForeignExchange,
+				Amount:        transfer.ToAmount,
+				Currency:      toAccount.Currency,
+			},
+		)
+	}
+
+	result, err := postJournal(ctx, q, description, &transfer.ID, postings)
+	return result.Journal, err
+}
+
+// balanced reports whether the postings sum to zero per currency and none of them is zero
+func balanced(postings []JournalPostingParams) bool {
+	if len(postings) < 2 {
+		return false
+	}
+
+	totals := make(map[string]int64)
+
+	for _, posting := range postings {
+		if posting.Amount == 0 {
+			return false
+		}
+
+		totals[posting.Currency] += posting.Amount
+	}
+
+	for _, total := range totals {
+		if total != 0 {
+			return false
+		}
+	}
+
+	return true
+}
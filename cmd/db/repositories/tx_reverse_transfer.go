@@ -0,0 +1,97 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"kara-bank/utils"
+)
+
+// ErrTransferAlreadyReversed is returned by ReverseTransferTx if the full amount of the transfer was already reversed
+var ErrTransferAlreadyReversed = errors.New("transfer is already reversed")
+
+// ErrReversalOfReversal is returned by ReverseTransferTx for transfers that reverse another transfer
+var ErrReversalOfReversal = errors.New("reversals cannot be reversed")
+
+// ErrInvalidReversalAmount is returned by ReverseTransferTx if the amount exceeds the part of the transfer that is not reversed yet
+// or is too small to be converted
+var ErrInvalidReversalAmount = errors.New("reversal amount exceeds the amount that is not reversed yet or is too small to be converted")
+
+type ReverseTransferTxParams struct {
+	TransferID int64 `json:"transfer_id"`
+	// credited back to the sending account of the original transfer in its currency. The remaining amount is reversed if not set
+	Amount int64 `json:"amount"`
+}
+
+// ReverseTransferTx sends the amount of a transfer back from the receiving to the sending account with a compensating transfer
+// that references the original transfer. Multiple partial reversals are possible until the full amount is reversed.
+func (store *SQLStore) ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (TransferTxResult, error) {
+	var result TransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// locking the original transfer serializes concurrent reversals, so that the reversed amount cannot exceed the transfer
+		original, err := q.GetTransferForUpdate(ctx, arg.TransferID)
+
+		if err != nil {
+			return err
+		}
+
+		if original.ReversalOf != nil {
+			return ErrReversalOfReversal
+		}
+
+		reversed, err := q.GetReversedAmount(ctx, original.ID)
+
+		if err != nil {
+			return err
+		}
+
+		remaining := original.Amount - reversed.ToAmount
+
+		if remaining <= 0 {
+			return ErrTransferAlreadyReversed
+		}
+
+		amount := arg.Amount
+
+		if amount == 0 {
+			amount = remaining
+		}
+
+		if amount < 0 || amount > remaining {
+			return ErrInvalidReversalAmount
+		}
+
+		// the receiving account pays back its share of the original to_amount. It is rounded on the total that is reversed,
+		// so that partial reversals add up to the original transfer
+		refunded := reversed.ToAmount + amount
+		share, err := utils.MulDivRound(refunded, original.ToAmount, original.Amount)
+
+		if err != nil {
+			return err
+		}
+
+		debit := share - reversed.Amount
+
+		if debit <= 0 {
+			return ErrInvalidReversalAmount
+		}
+
+		exchangeRate, err := utils.RatioExchangeRate(debit, amount)
+
+		if err != nil {
+			return err
+		}
+
+		result, err = transfer(ctx, q, TransferTxParams{
+			FromAccountID: original.ToAccountID,
+			ToAccountID:   original.FromAccountID,
+			Amount:        debit,
+			ToAmount:      amount,
+			ExchangeRate:  exchangeRate,
+		}, transferOptions{reversalOf: &original.ID})
+
+		return err
+	})
+
+	return result, err
+}
@@ -1,11 +1,37 @@
 package db
 
-import "context"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kara-bank/utils"
+)
+
+// categories of the entries that are created by the app
+const (
+	EntryTransfer = "transfer"
+	EntryReversal = "reversal"
+	// default category of entries of manual journals
+	EntryJournal = "journal"
+)
+
+// ErrInsufficientFunds is returned by TransferTx if the transfer would take the available balance of the sending account below its
+// overdraft limit. Money that is held on the account is not available
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// ErrCurrencyMismatch is returned by TransferTx if the accounts have different currencies and no conversion is given
+var ErrCurrencyMismatch = errors.New("accounts have different currencies")
 
 type TransferTxParams struct {
 	FromAccountID int64 `json:"from_account_id"`
 	ToAccountID   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	// debited from the sending account in its currency
+	Amount int64 `json:"amount"`
+	// credited to the receiving account, only used if the accounts have different currencies
+	ToAmount     int64              `json:"to_amount"`
+	ExchangeRate utils.ExchangeRate `json:"exchange_rate"`
+	// optional, runs within the transaction after the money was transferred. An error rolls back the transfer
+	AfterTransfer func(q *Queries, result TransferTxResult) error `json:"-"`
 }
 
 type TransferTxResult struct {
@@ -23,52 +49,154 @@ func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (Tr
 
 	err := store.execTx(ctx, func(q *Queries) error {
 		var err error
+		result, err = transfer(ctx, q, arg, transferOptions{})
+		return err
+	})
 
-		result.Transfer, err = q.CreateTransfer(ctx, &CreateTransferParams{
-			FromAccountID: arg.FromAccountID,
-			ToAccountID:   arg.ToAccountID,
-			Amount:        arg.Amount,
-		})
+	return result, err
+}
 
-		if err != nil {
-			return err
-		}
+// transferOptions are used by the transactions that build on TransferTx
+type transferOptions struct {
+	// transfer that is reversed by this transfer
+	reversalOf *int64
+	// amount of the hold that is captured by this transfer, it is released when the money is transferred
+	releaseHold int64
+}
 
-		result.FromEntry, err = q.CreateEntry(ctx, &CreateEntryParams{
-			AccountID: arg.FromAccountID,
-			Amount:    -arg.Amount,
-		})
+// transfer runs the steps of TransferTx within the given transaction
+func transfer(ctx context.Context, q *Queries, arg TransferTxParams, opts transferOptions) (TransferTxResult, error) {
+	var result TransferTxResult
 
-		if err != nil {
-			return err
+	fromAccount, toAccount, err := lockAccounts(ctx, q, arg.FromAccountID, arg.ToAccountID)
+
+	if err != nil {
+		return result, err
+	}
+
+	// held money is not available, except the hold that is captured by this transfer
+	if fromAccount.AvailableBalance+opts.releaseHold-arg.Amount < -fromAccount.OverdraftLimit {
+		return result, ErrInsufficientFunds
+	}
+
+	toAmount, exchangeRate := arg.Amount, utils.ExchangeRateScale
+
+	if fromAccount.Currency != toAccount.Currency {
+		if arg.ToAmount <= 0 || arg.ExchangeRate <= 0 {
+			return result, ErrCurrencyMismatch
 		}
 
-		result.ToEntry, err = q.CreateEntry(ctx, &CreateEntryParams{
-			AccountID: arg.ToAccountID,
-			Amount:    arg.Amount,
+		toAmount, exchangeRate = arg.ToAmount, arg.ExchangeRate
+	}
+
+	result.Transfer, err = q.CreateTransfer(ctx, &CreateTransferParams{
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+		ToAmount:      toAmount,
+		ExchangeRate:  exchangeRate,
+		ReversalOf:    opts.reversalOf,
+	})
+
+	if err != nil {
+		return result, err
+	}
+
+	journal, err := postTransferJournal(ctx, q, result.Transfer, fromAccount, toAccount)
+
+	if err != nil {
+		return result, err
+	}
+
+	category := EntryTransfer
+
+	if opts.reversalOf != nil {
+		category = EntryReversal
+	}
+
+	result.FromEntry, err = q.CreateEntry(ctx, &CreateEntryParams{
+		AccountID:   arg.FromAccountID,
+		Amount:      -arg.Amount,
+		TransferID:  &result.Transfer.ID,
+		JournalID:   &journal.ID,
+		Description: fmt.Sprintf("transfer to account %d", arg.ToAccountID),
+		Category:    category,
+	})
+
+	if err != nil {
+		return result, err
+	}
+
+	result.ToEntry, err = q.CreateEntry(ctx, &CreateEntryParams{
+		AccountID:   arg.ToAccountID,
+		Amount:      toAmount,
+		TransferID:  &result.Transfer.ID,
+		JournalID:   &journal.ID,
+		Description: fmt.Sprintf("transfer from account %d", arg.FromAccountID),
+		Category:    category,
+	})
+
+	if err != nil {
+		return result, err
+	}
+
+	if opts.releaseHold > 0 {
+		_, err = q.AddAccountHeldAmount(ctx, &AddAccountHeldAmountParams{
+			ID:     arg.FromAccountID,
+			Amount: -opts.releaseHold,
 		})
 
 		if err != nil {
-			return err
+			return result, err
 		}
+	}
 
-		// to prevent a deadlock during multiple concurrent transactions make sure that the account with the lower ID is used first to transfer money
-		if arg.FromAccountID < arg.ToAccountID {
-			result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.FromAccountID, -arg.Amount, arg.ToAccountID, arg.Amount)
-			if err != nil {
-				return err
-			}
-		} else {
-			result.ToAccount, result.FromAccount, err = addMoney(ctx, q, arg.ToAccountID, arg.Amount, arg.FromAccountID, -arg.Amount)
-			if err != nil {
-				return err
-			}
+	// to prevent a deadlock during multiple concurrent transactions make sure that the account with the lower ID is used first to transfer money
+	if arg.FromAccountID < arg.ToAccountID {
+		result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.FromAccountID, -arg.Amount, arg.ToAccountID, toAmount)
+		if err != nil {
+			return result, err
+		}
+	} else {
+		result.ToAccount, result.FromAccount, err = addMoney(ctx, q, arg.ToAccountID, toAmount, arg.FromAccountID, -arg.Amount)
+		if err != nil {
+			return result, err
 		}
+	}
 
-		return nil
-	})
+	if arg.AfterTransfer != nil {
+		return result, arg.AfterTransfer(q, result)
+	}
 
-	return result, err
+	return result, nil
+}
+
+// lockAccounts locks both accounts of a transfer for the rest of the transaction and returns the sending and the receiving account.
+// The accounts are locked in the order of their IDs, like in addMoney, to prevent deadlocks between concurrent transfers.
+func lockAccounts(ctx context.Context, q *Queries, fromAccountID int64, toAccountID int64) (*Account, *Account, error) {
+	firstID, secondID := fromAccountID, toAccountID
+
+	if toAccountID < fromAccountID {
+		firstID, secondID = toAccountID, fromAccountID
+	}
+
+	first, err := q.GetAccountForUpdate(ctx, firstID)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	second, err := q.GetAccountForUpdate(ctx, secondID)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if first.ID == fromAccountID {
+		return first, second, nil
+	}
+
+	return second, first, nil
 }
 
 func addMoney(
@@ -0,0 +1,169 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kara-bank/utils"
+)
+
+// states of a transfer approval
+const (
+	TransferApprovalPending  = "pending"
+	TransferApprovalApproved = "approved"
+	TransferApprovalRejected = "rejected"
+)
+
+// ErrApprovalDecided is returned if a transfer approval was already approved or rejected
+var ErrApprovalDecided = errors.New("transfer is already approved or rejected")
+
+// ErrApprovalByInitiator is returned if the initiator of a transfer tries to approve or reject it
+var ErrApprovalByInitiator = errors.New("transfers cannot be approved or rejected by their initiator")
+
+type DecideTransferApprovalTxParams struct {
+	ApprovalID int64  `json:"approval_id"`
+	Reviewer   string `json:"reviewer"`
+	// credited to the receiving account, only used if the accounts have different currencies
+	ToAmount     int64              `json:"to_amount"`
+	ExchangeRate utils.ExchangeRate `json:"exchange_rate"`
+}
+
+type CreateTransferApprovalTxParams struct {
+	CreateTransferApprovalParams
+	// optional, runs within the transaction after the approval was created. An error rolls back the approval and its hold
+	AfterCreate func(q *Queries, approval *TransferApproval) error `json:"-"`
+}
+
+type ApproveTransferTxResult struct {
+	Approval *TransferApproval `json:"approval"`
+	TransferTxResult
+}
+
+// CreateTransferApprovalTx holds the amount on the sending account and creates a pending transfer that is executed
+// once a banker approves it. The held amount is not available for other transfers in the meantime.
+func (store *SQLStore) CreateTransferApprovalTx(ctx context.Context, arg CreateTransferApprovalTxParams) (*TransferApproval, error) {
+	var approval *TransferApproval
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		hold, err := placeHold(ctx, q, CreateHoldParams{
+			AccountID:   arg.FromAccountID,
+			Amount:      arg.Amount,
+			Description: fmt.Sprintf("pending transfer to account %d", arg.ToAccountID),
+		})
+
+		if err != nil {
+			return err
+		}
+
+		arg.HoldID = &hold.ID
+		approval, err = q.CreateTransferApproval(ctx, &arg.CreateTransferApprovalParams)
+
+		if err != nil {
+			return err
+		}
+
+		if arg.AfterCreate != nil {
+			return arg.AfterCreate(q, approval)
+		}
+
+		return nil
+	})
+
+	return approval, err
+}
+
+// ApproveTransferTx executes a pending transfer by capturing its hold and records the reviewer
+func (store *SQLStore) ApproveTransferTx(ctx context.Context, arg DecideTransferApprovalTxParams) (ApproveTransferTxResult, error) {
+	var result ApproveTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		approval, err := lockPendingApproval(ctx, q, arg.ApprovalID, arg.Reviewer)
+
+		if err != nil {
+			return err
+		}
+
+		hold, err := lockActiveHold(ctx, q, *approval.HoldID)
+
+		if err != nil {
+			return err
+		}
+
+		_, result.TransferTxResult, err = captureHold(ctx, q, hold, TransferTxParams{
+			FromAccountID: approval.FromAccountID,
+			ToAccountID:   approval.ToAccountID,
+			Amount:        approval.Amount,
+			ToAmount:      arg.ToAmount,
+			ExchangeRate:  arg.ExchangeRate,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		result.Approval, err = q.DecideTransferApproval(ctx, &DecideTransferApprovalParams{
+			ID:         approval.ID,
+			Status:     TransferApprovalApproved,
+			Reviewer:   &arg.Reviewer,
+			TransferID: &result.Transfer.ID,
+		})
+
+		return err
+	})
+
+	return result, err
+}
+
+// RejectTransferTx releases the hold of a pending transfer without executing it
+func (store *SQLStore) RejectTransferTx(ctx context.Context, arg DecideTransferApprovalTxParams) (*TransferApproval, error) {
+	var result *TransferApproval
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		approval, err := lockPendingApproval(ctx, q, arg.ApprovalID, arg.Reviewer)
+
+		if err != nil {
+			return err
+		}
+
+		hold, err := lockActiveHold(ctx, q, *approval.HoldID)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = releaseHold(ctx, q, hold, HoldReleased)
+
+		if err != nil {
+			return err
+		}
+
+		result, err = q.DecideTransferApproval(ctx, &DecideTransferApprovalParams{
+			ID:       approval.ID,
+			Status:   TransferApprovalRejected,
+			Reviewer: &arg.Reviewer,
+		})
+
+		return err
+	})
+
+	return result, err
+}
+
+// lockPendingApproval locks the approval for the rest of the transaction, so that it is decided only once
+func lockPendingApproval(ctx context.Context, q *Queries, id int64, reviewer string) (*TransferApproval, error) {
+	approval, err := q.GetTransferApprovalForUpdate(ctx, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if approval.Status != TransferApprovalPending {
+		return nil, ErrApprovalDecided
+	}
+
+	if approval.Initiator == reviewer {
+		return nil, ErrApprovalByInitiator
+	}
+
+	return approval, nil
+}
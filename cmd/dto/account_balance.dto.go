@@ -0,0 +1,16 @@
+package dto
+
+import "time"
+
+type GetAccountBalanceDto struct {
+	AccountId int64     `validate:"required,min=1"`
+	At        time.Time `validate:"required"`
+}
+
+// AccountBalanceDto is the balance of an account at a point in time, the sum of the entries created until then
+type AccountBalanceDto struct {
+	AccountId int64     `json:"account_id"`
+	Currency  string    `json:"currency"`
+	Balance   int64     `json:"balance"`
+	At        time.Time `json:"at"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+type CaptureHoldDto struct {
+	HoldId      int64 `validate:"required,min=1"`
+	ToAccountId int64 `json:"to_account_id" validate:"required,min=1"`
+	// optional, amount that is transferred in the currency of the held account. The full held amount is captured if not set
+	Amount int64 `json:"amount" validate:"omitempty,gt=0"`
+}
@@ -3,4 +3,6 @@ package dto
 type CreateAccountDto struct {
 	Owner    string `validate:"required,email"`
 	Currency string `json:"currency" validate:"required,oneof=EUR USD"`
+	// optional, retries with the same key return the original account
+	IdempotencyKey string `json:"-" validate:"omitempty,max=255"`
 }
@@ -0,0 +1,11 @@
+package dto
+
+import "time"
+
+type CreateHoldDto struct {
+	AccountId   int64  `validate:"required,min=1"`
+	Amount      int64  `json:"amount" validate:"required,gt=0"`
+	Description string `json:"description" validate:"max=255"`
+	// the hold is released automatically after this time, 7 days after the creation if not set
+	ExpiresAt *time.Time `json:"expires_at"`
+}
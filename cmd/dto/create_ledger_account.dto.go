@@ -0,0 +1,7 @@
+package dto
+
+type CreateLedgerAccountDto struct {
+	Code string `json:"code" validate:"required,numeric,max=10"`
+	Name string `json:"name" validate:"required,max=255"`
+	Type string `json:"type" validate:"required,oneof=asset liability equity income expense"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+type CreateStandingOrderDto struct {
+	Owner         string `validate:"required,email"`
+	FromAccountId int64  `json:"from_account_id" validate:"required,min=1"`
+	ToAccountId   int64  `json:"to_account_id" validate:"required,min=1,nefield=FromAccountId"`
+	Amount        int64  `json:"amount" validate:"required,gt=0"`
+	Frequency     string `json:"frequency" validate:"required,oneof=once daily weekly monthly"`
+	// first execution, the order is executed right away if not set
+	StartAt *time.Time `json:"start_at"`
+	// optional, no executions are scheduled after this time
+	EndAt *time.Time `json:"end_at"`
+}
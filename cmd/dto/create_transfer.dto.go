@@ -5,4 +5,6 @@ type CreateTransferDto struct {
 	FromAccountId int64  `json:"from_account_id" validate:"required,min=1"`
 	ToAccountId   int64  `json:"to_account_id" validate:"required,min=1"`
 	Amount        int64  `json:"amount" validate:"required,gt=0"`
+	// optional, retries with the same key return the original transfer
+	IdempotencyKey string `json:"-" validate:"omitempty,max=255"`
 }
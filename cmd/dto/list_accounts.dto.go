@@ -1,6 +1,6 @@
 package dto
 
 type ListAccountsDto struct {
-	Limit  int32 `validate:"required,min=1"`
-	Offset int32 `validate:"gte=0"`
+	Limit  int32 `validate:"min=1,max=1000"`
+	Cursor string
 }
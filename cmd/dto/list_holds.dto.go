@@ -0,0 +1,7 @@
+package dto
+
+type ListHoldsDto struct {
+	AccountId int64 `validate:"required,min=1"`
+	Limit     int32 `validate:"min=1,max=1000"`
+	Cursor    string
+}
@@ -0,0 +1,6 @@
+package dto
+
+type ListTransferApprovalsDto struct {
+	Limit  int32 `validate:"min=1,max=1000"`
+	Cursor string
+}
@@ -0,0 +1,19 @@
+package dto
+
+import "time"
+
+// directions to filter the transfer and entry history of an account
+const (
+	DirectionIncoming = "incoming"
+	DirectionOutgoing = "outgoing"
+)
+
+type ListTransfersDto struct {
+	AccountId int64 `validate:"required,min=1"`
+	Limit     int32 `validate:"min=1,max=1000"`
+	Cursor    string
+	// optional filters, the end time is exclusive
+	StartTime *time.Time
+	EndTime   *time.Time
+	Direction string `validate:"omitempty,oneof=incoming outgoing"`
+}
@@ -1,8 +1,19 @@
 package dto
 
+import "time"
+
 type LoginUserDto struct {
 	Email     string `json:"email" validate:"required,email"`
 	Password  string `json:"password" validate:"required"`
 	UserAgent string `validate:"required"`
 	ClientIp  string `validate:"required"`
+	// clients that cannot use cookies get the tokens in the response body
+	ReturnTokens bool `json:"return_tokens"`
+}
+
+type LoginUserResultDto struct {
+	AccessToken           string    `json:"access_token"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
 }
@@ -0,0 +1,21 @@
+package dto
+
+// PageDto is the response envelope of all list endpoints. NextCursor is sent as cursor to get the next page
+// and is empty if there are no more items.
+type PageDto[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor"`
+	HasMore    bool   `json:"has_more"`
+}
+
+// DefaultPageSize is the limit of the list endpoints if the client does not send one
+const DefaultPageSize int32 = 100
+
+// PageSize returns the requested limit or DefaultPageSize if the limit is not set
+func PageSize(limit int32) int32 {
+	if limit == 0 {
+		return DefaultPageSize
+	}
+
+	return limit
+}
@@ -0,0 +1,17 @@
+package dto
+
+type PostJournalDto struct {
+	Description string `json:"description" validate:"required,max=255"`
+	// optional, category of the entries on customer accounts, e.g. fee or interest. Defaults to journal
+	Category string           `json:"category" validate:"max=50"`
+	Postings []JournalPosting `json:"postings" validate:"required,min=2,dive"`
+}
+
+type JournalPosting struct {
+	LedgerAccount string `json:"ledger_account" validate:"required"`
+	// customer account, required for and only allowed on the customer deposits ledger account
+	AccountId *int64 `json:"account_id" validate:"omitempty,min=1"`
+	// debit if positive, credit if negative
+	Amount   int64  `json:"amount" validate:"required"`
+	Currency string `json:"currency" validate:"required,oneof=EUR USD"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+type RefreshTokenDto struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type RefreshTokenResultDto struct {
+	AccessToken          string    `json:"access_token"`
+	AccessTokenExpiresAt time.Time `json:"access_token_expires_at"`
+}
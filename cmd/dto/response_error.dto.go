@@ -3,4 +3,30 @@ package dto
 type ResponseError struct {
 	Message string `json:"message"`
 	Status  int    `json:"status_code"`
+	// optional machine readable reason of domain errors, e.g. ReasonInsufficientFunds.
+	// Errors without a reason are identified by their status
+	Reason string `json:"reason,omitempty"`
 }
+
+// reasons of the domain errors that share a status with other errors
+const (
+	ReasonInsufficientFunds        = "INSUFFICIENT_FUNDS"
+	ReasonCurrencyMismatch         = "CURRENCY_MISMATCH"
+	ReasonExchangeRateNotFound     = "EXCHANGE_RATE_NOT_FOUND"
+	ReasonAmountTooSmall           = "AMOUNT_TOO_SMALL"
+	ReasonAmountTooLarge           = "AMOUNT_TOO_LARGE"
+	ReasonOverdraftLimitBelowDebt  = "OVERDRAFT_LIMIT_BELOW_DEBT"
+	ReasonTransferAlreadyReversed  = "TRANSFER_ALREADY_REVERSED"
+	ReasonInvalidReversal          = "INVALID_REVERSAL"
+	ReasonApprovalRequired         = "APPROVAL_REQUIRED"
+	ReasonApprovalDecided          = "APPROVAL_ALREADY_DECIDED"
+	ReasonHoldClosed               = "HOLD_CLOSED"
+	ReasonInvalidCaptureAmount     = "INVALID_CAPTURE_AMOUNT"
+	ReasonStandingOrderNotActive   = "STANDING_ORDER_NOT_ACTIVE"
+	ReasonConcurrentUpdate         = "CONCURRENT_UPDATE"
+	ReasonIdempotencyKeyInProgress = "IDEMPOTENCY_KEY_IN_PROGRESS"
+	ReasonIdempotencyKeyReused     = "IDEMPOTENCY_KEY_REUSED"
+	ReasonUnbalancedJournal        = "UNBALANCED_JOURNAL"
+	ReasonInvalidPosting           = "INVALID_POSTING"
+	ReasonLedgerAccountNotFound    = "LEDGER_ACCOUNT_NOT_FOUND"
+)
@@ -0,0 +1,7 @@
+package dto
+
+type ReverseTransferDto struct {
+	TransferId int64 `validate:"required,min=1"`
+	// optional, amount that is sent back to the sender in the currency of the original transfer. The remaining amount is reversed if not set
+	Amount int64 `json:"amount" validate:"omitempty,gt=0"`
+}
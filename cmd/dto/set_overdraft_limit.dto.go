@@ -0,0 +1,6 @@
+package dto
+
+type SetOverdraftLimitDto struct {
+	AccountId      int64 `validate:"required,min=1"`
+	OverdraftLimit int64 `json:"overdraft_limit" validate:"gte=0"`
+}
@@ -0,0 +1,11 @@
+package dto
+
+import "time"
+
+type UpdateStandingOrderDto struct {
+	Id     int64  `validate:"required,min=1"`
+	Owner  string `validate:"required,email"`
+	Amount int64  `json:"amount" validate:"required,gt=0"`
+	// optional, no executions are scheduled after this time
+	EndAt *time.Time `json:"end_at"`
+}
@@ -0,0 +1,29 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/pb"
+)
+
+func (s GrpcServer) ApproveTransfer(ctx context.Context, req *pb.ApproveTransferRequest) (*pb.ApproveTransferResponse, error) {
+	user, err := getAuthenticatedUser(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result, respErr := s.transerService.ApproveTransfer(ctx, req.Id, user.email)
+
+	if respErr != nil {
+		return nil, responseError(respErr)
+	}
+
+	return &pb.ApproveTransferResponse{
+		Approval:    convertTransferApproval(result.Approval),
+		Transfer:    convertTransfer(result.Transfer),
+		FromAccount: convertAccount(result.FromAccount),
+		ToAccount:   convertAccount(result.ToAccount),
+		FromEntry:   convertEntry(result.FromEntry),
+		ToEntry:     convertEntry(result.ToEntry),
+	}, nil
+}
@@ -0,0 +1,224 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"kara-bank/middlewares"
+	"kara-bank/pb"
+	"kara-bank/services"
+	"kara-bank/utils"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationHeader = "authorization"
+	authorizationBearer = "bearer"
+)
+
+// methodPolicies are the grpc counterpart of the route policies registered in server.InitHttpServer.
+// Methods without a policy are rejected.
+var methodPolicies = map[string]utils.RoutePolicy{
+	pb.KaraBank_RegisterUser_FullMethodName:  utils.PublicRoute(),
+	pb.KaraBank_LoginUser_FullMethodName:     utils.PublicRoute(),
+	pb.KaraBank_RefreshToken_FullMethodName:  utils.PublicRoute(),
+	pb.KaraBank_ListSessions_FullMethodName:  utils.AllowRoles(utils.AllRoles...),
+	pb.KaraBank_RevokeSession_FullMethodName: utils.AllowRoles(utils.AllRoles...),
+
+	pb.KaraBank_CreateAccount_FullMethodName:     utils.AllowRoles(utils.CustomerRole),
+	pb.KaraBank_GetAccount_FullMethodName:        utils.AllowRoles(utils.AllRoles...),
+	pb.KaraBank_ListAccounts_FullMethodName:      utils.AllowRoles(utils.BankerRole, utils.AdminRole),
+	pb.KaraBank_ListOwnAccounts_FullMethodName:   utils.AllowRoles(utils.AllRoles...),
+	pb.KaraBank_ListEntries_FullMethodName:       utils.AllowRoles(utils.AllRoles...),
+	pb.KaraBank_GetAccountBalance_FullMethodName: utils.AllowRoles(utils.AllRoles...),
+
+	pb.KaraBank_SetOverdraftLimit_FullMethodName: utils.AllowRoles(utils.BankerRole, utils.AdminRole),
+
+	pb.KaraBank_CreateTransfer_FullMethodName:  utils.AllowRoles(utils.CustomerRole),
+	pb.KaraBank_GetTransfer_FullMethodName:     utils.AllowRoles(utils.AllRoles...),
+	pb.KaraBank_ListTransfers_FullMethodName:   utils.AllowRoles(utils.AllRoles...),
+	pb.KaraBank_ReverseTransfer_FullMethodName: utils.AllowRoles(utils.BankerRole, utils.AdminRole),
+
+	pb.KaraBank_ListTransferApprovals_FullMethodName: utils.AllowRoles(utils.BankerRole, utils.AdminRole),
+	pb.KaraBank_GetTransferApproval_FullMethodName:   utils.AllowRoles(utils.AllRoles...),
+	pb.KaraBank_ApproveTransfer_FullMethodName:       utils.AllowRoles(utils.BankerRole),
+	pb.KaraBank_RejectTransfer_FullMethodName:        utils.AllowRoles(utils.BankerRole),
+
+	pb.KaraBank_CreateHold_FullMethodName:  utils.AllowRoles(utils.BankerRole, utils.AdminRole),
+	pb.KaraBank_ListHolds_FullMethodName:   utils.AllowRoles(utils.AllRoles...),
+	pb.KaraBank_GetHold_FullMethodName:     utils.AllowRoles(utils.AllRoles...),
+	pb.KaraBank_CaptureHold_FullMethodName: utils.AllowRoles(utils.BankerRole, utils.AdminRole),
+	pb.KaraBank_ReleaseHold_FullMethodName: utils.AllowRoles(utils.BankerRole, utils.AdminRole),
+
+	pb.KaraBank_CreateStandingOrder_FullMethodName: utils.AllowRoles(utils.CustomerRole),
+	pb.KaraBank_GetStandingOrder_FullMethodName:    utils.AllowRoles(utils.AllRoles...),
+	pb.KaraBank_ListStandingOrders_FullMethodName:  utils.AllowRoles(utils.CustomerRole),
+	pb.KaraBank_UpdateStandingOrder_FullMethodName: utils.AllowRoles(utils.CustomerRole),
+	pb.KaraBank_CancelStandingOrder_FullMethodName: utils.AllowRoles(utils.CustomerRole),
+
+	pb.KaraBank_ListLedgerAccounts_FullMethodName:  utils.AllowRoles(utils.BankerRole, utils.AdminRole),
+	pb.KaraBank_CreateLedgerAccount_FullMethodName: utils.AllowRoles(utils.AdminRole),
+	pb.KaraBank_PostJournal_FullMethodName:         utils.AllowRoles(utils.AdminRole),
+	pb.KaraBank_GetJournal_FullMethodName:          utils.AllowRoles(utils.BankerRole, utils.AdminRole),
+	pb.KaraBank_GetTrialBalance_FullMethodName:     utils.AllowRoles(utils.BankerRole, utils.AdminRole),
+
+	grpc_reflection_v1.ServerReflection_ServerReflectionInfo_FullMethodName:      utils.PublicRoute(),
+	grpc_reflection_v1alpha.ServerReflection_ServerReflectionInfo_FullMethodName: utils.PublicRoute(),
+}
+
+type AuthInterceptor struct {
+	tokenMaker  utils.TokenMaker
+	userService services.UserServiceInterface
+	policies    map[string]utils.RoutePolicy
+}
+
+func NewAuthInterceptor(tokenMaker utils.TokenMaker, userService services.UserServiceInterface) *AuthInterceptor {
+	return &AuthInterceptor{
+		tokenMaker:  tokenMaker,
+		userService: userService,
+		policies:    methodPolicies,
+	}
+}
+
+func (i *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := i.authorize(ctx, info.FullMethod)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func (i *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := i.authorize(stream.Context(), info.FullMethod)
+
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authorizedServerStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// authorize applies the policy of the method and returns a context carrying email, role and session id
+// of the caller, using the same context keys as middlewares.AuthMiddleware
+func (i *AuthInterceptor) authorize(ctx context.Context, method string) (context.Context, error) {
+	policy, ok := i.policies[method]
+
+	if !ok {
+		return nil, status.Errorf(codes.PermissionDenied, "no policy for method %s", method)
+	}
+
+	if policy.Public {
+		return ctx, nil
+	}
+
+	accessToken, err := getBearerToken(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := i.tokenMaker.VerifyToken(accessToken)
+
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, fmt.Sprintf("invalid access token: %v", err))
+	}
+
+	// refresh tokens live much longer and must only be sent to the refresh endpoint
+	if payload.Type != utils.AccessTokenType {
+		return nil, status.Error(codes.Unauthenticated, fmt.Sprintf("invalid access token: %v", utils.ErrWrongTokenType))
+	}
+
+	respErr := i.userService.ValidateSession(ctx, payload.SessionID, payload.Email)
+
+	if respErr != nil {
+		return nil, status.Error(codes.Unauthenticated, respErr.Message)
+	}
+
+	if !policy.Allows(payload.Role) {
+		return nil, status.Error(codes.PermissionDenied, "You do not have the right role to do this")
+	}
+
+	ctx = context.WithValue(ctx, middlewares.ContextUserEmailKey, payload.Email)
+	ctx = context.WithValue(ctx, middlewares.ContextUserRoleKey, payload.Role)
+	ctx = context.WithValue(ctx, middlewares.ContextSessionIdKey, payload.SessionID)
+
+	return ctx, nil
+}
+
+type authenticatedUser struct {
+	email     string
+	role      string
+	sessionId uuid.UUID
+}
+
+// getAuthenticatedUser reads the caller that the interceptor injected into the context
+func getAuthenticatedUser(ctx context.Context) (*authenticatedUser, error) {
+	email, ok := ctx.Value(middlewares.ContextUserEmailKey).(string)
+
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "Could not read user email")
+	}
+
+	role, ok := ctx.Value(middlewares.ContextUserRoleKey).(string)
+
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "Could not read user role")
+	}
+
+	sessionId, ok := ctx.Value(middlewares.ContextSessionIdKey).(uuid.UUID)
+
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "Could not read session id")
+	}
+
+	return &authenticatedUser{
+		email:     email,
+		role:      role,
+		sessionId: sessionId,
+	}, nil
+}
+
+func getBearerToken(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "missing metadata")
+	}
+
+	values := md.Get(authorizationHeader)
+
+	if len(values) == 0 {
+		return "", status.Error(codes.Unauthenticated, "missing authorization header")
+	}
+
+	fields := strings.Fields(values[0])
+
+	if len(fields) != 2 || strings.ToLower(fields[0]) != authorizationBearer {
+		return "", status.Error(codes.Unauthenticated, "invalid authorization header format")
+	}
+
+	return fields[1], nil
+}
+
+// authorizedServerStream replaces the context of a stream with the authorized context
+type authorizedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authorizedServerStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,165 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/dto"
+	"kara-bank/middlewares"
+	"kara-bank/pb"
+	"kara-bank/services"
+	"kara-bank/utils"
+	"net/http"
+	"testing"
+	"time"
+
+	"aidanwoods.dev/go-paseto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// sessionValidator stubs the session check of the user service, all other methods are not used by the interceptor
+type sessionValidator struct {
+	services.UserServiceInterface
+	blocked bool
+}
+
+func (v sessionValidator) ValidateSession(ctx context.Context, sessionId uuid.UUID, email string) *dto.ResponseError {
+	if v.blocked {
+		return &dto.ResponseError{
+			Message: "Session is blocked",
+			Status:  http.StatusUnauthorized,
+		}
+	}
+
+	return nil
+}
+
+func newTestInterceptor(t *testing.T, blocked bool) (*AuthInterceptor, utils.TokenMaker) {
+	tokenMaker, err := utils.NewPasetoMaker("test", map[string]paseto.V4SymmetricKey{
+		"test": paseto.NewV4SymmetricKey(),
+	})
+	require.NoError(t, err)
+
+	return NewAuthInterceptor(tokenMaker, sessionValidator{blocked: blocked}), tokenMaker
+}
+
+func contextWithToken(t *testing.T, tokenMaker utils.TokenMaker, role string, sessionId uuid.UUID) context.Context {
+	return contextWithTokenType(t, tokenMaker, role, sessionId, utils.AccessTokenType)
+}
+
+func contextWithTokenType(t *testing.T, tokenMaker utils.TokenMaker, role string, sessionId uuid.UUID, tokenType string) context.Context {
+	token, _, err := tokenMaker.CreateToken("Max@Mustermann.de", role, sessionId, tokenType, time.Minute)
+	require.NoError(t, err)
+
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(authorizationHeader, "Bearer "+token))
+}
+
+func callUnary(interceptor *AuthInterceptor, ctx context.Context, method string) (context.Context, error) {
+	var handlerCtx context.Context
+
+	_, err := interceptor.Unary()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req any) (any, error) {
+		handlerCtx = ctx
+		return nil, nil
+	})
+
+	return handlerCtx, err
+}
+
+func TestAuthInterceptorPublicMethod(t *testing.T) {
+	interceptor, _ := newTestInterceptor(t, false)
+
+	_, err := callUnary(interceptor, context.Background(), pb.KaraBank_LoginUser_FullMethodName)
+	require.NoError(t, err)
+}
+
+func TestAuthInterceptorUnknownMethod(t *testing.T) {
+	interceptor, _ := newTestInterceptor(t, false)
+
+	_, err := callUnary(interceptor, context.Background(), "/pb.KaraBank/Unknown")
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestAuthInterceptorMissingToken(t *testing.T) {
+	interceptor, _ := newTestInterceptor(t, false)
+
+	_, err := callUnary(interceptor, context.Background(), pb.KaraBank_ListSessions_FullMethodName)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestAuthInterceptorInvalidHeader(t *testing.T) {
+	interceptor, _ := newTestInterceptor(t, false)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(authorizationHeader, "Basic abc"))
+
+	_, err := callUnary(interceptor, ctx, pb.KaraBank_ListSessions_FullMethodName)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestAuthInterceptorBlockedSession(t *testing.T) {
+	interceptor, tokenMaker := newTestInterceptor(t, true)
+	ctx := contextWithToken(t, tokenMaker, utils.CustomerRole, uuid.New())
+
+	_, err := callUnary(interceptor, ctx, pb.KaraBank_ListSessions_FullMethodName)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestAuthInterceptorRefreshToken(t *testing.T) {
+	interceptor, tokenMaker := newTestInterceptor(t, false)
+	ctx := contextWithTokenType(t, tokenMaker, utils.CustomerRole, uuid.New(), utils.RefreshTokenType)
+
+	_, err := callUnary(interceptor, ctx, pb.KaraBank_ListSessions_FullMethodName)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestAuthInterceptorWrongRole(t *testing.T) {
+	interceptor, tokenMaker := newTestInterceptor(t, false)
+	interceptor.policies = map[string]utils.RoutePolicy{
+		"/pb.KaraBank/BankerOnly": utils.AllowRoles(utils.BankerRole),
+	}
+	ctx := contextWithToken(t, tokenMaker, utils.CustomerRole, uuid.New())
+
+	_, err := callUnary(interceptor, ctx, "/pb.KaraBank/BankerOnly")
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestAuthInterceptorInjectsUser(t *testing.T) {
+	interceptor, tokenMaker := newTestInterceptor(t, false)
+	sessionId := uuid.New()
+	ctx := contextWithToken(t, tokenMaker, utils.CustomerRole, sessionId)
+
+	handlerCtx, err := callUnary(interceptor, ctx, pb.KaraBank_ListSessions_FullMethodName)
+	require.NoError(t, err)
+	require.Equal(t, "Max@Mustermann.de", handlerCtx.Value(middlewares.ContextUserEmailKey))
+	require.Equal(t, utils.CustomerRole, handlerCtx.Value(middlewares.ContextUserRoleKey))
+	require.Equal(t, sessionId, handlerCtx.Value(middlewares.ContextSessionIdKey))
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestAuthInterceptorStream(t *testing.T) {
+	interceptor, tokenMaker := newTestInterceptor(t, false)
+	interceptor.policies = map[string]utils.RoutePolicy{
+		"/pb.KaraBank/Stream": utils.AllowRoles(utils.AllRoles...),
+	}
+	stream := testServerStream{ctx: contextWithToken(t, tokenMaker, utils.BankerRole, uuid.New())}
+
+	err := interceptor.Stream()(nil, stream, &grpc.StreamServerInfo{FullMethod: "/pb.KaraBank/Stream"}, func(srv any, stream grpc.ServerStream) error {
+		require.Equal(t, utils.BankerRole, stream.Context().Value(middlewares.ContextUserRoleKey))
+		return nil
+	})
+	require.NoError(t, err)
+
+	err = interceptor.Stream()(nil, testServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/pb.KaraBank/Stream"}, func(srv any, stream grpc.ServerStream) error {
+		return nil
+	})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
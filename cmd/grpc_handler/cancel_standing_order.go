@@ -0,0 +1,24 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/pb"
+)
+
+func (s GrpcServer) CancelStandingOrder(ctx context.Context, req *pb.CancelStandingOrderRequest) (*pb.CancelStandingOrderResponse, error) {
+	user, err := getAuthenticatedUser(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	standingOrder, respErr := s.standingOrderService.CancelStandingOrder(ctx, req.Id, user.email)
+
+	if respErr != nil {
+		return nil, responseError(respErr)
+	}
+
+	return &pb.CancelStandingOrderResponse{
+		StandingOrder: convertStandingOrder(standingOrder),
+	}, nil
+}
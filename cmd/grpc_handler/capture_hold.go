@@ -0,0 +1,36 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/dto"
+	"kara-bank/pb"
+)
+
+func (s GrpcServer) CaptureHold(ctx context.Context, req *pb.CaptureHoldRequest) (*pb.CaptureHoldResponse, error) {
+	args := &dto.CaptureHoldDto{
+		HoldId:      req.HoldId,
+		ToAccountId: req.ToAccountId,
+		Amount:      req.Amount,
+	}
+
+	err := s.validator.Struct(args)
+
+	if err != nil {
+		return nil, validationError(err)
+	}
+
+	result, respErr := s.holdService.CaptureHold(ctx, args)
+
+	if respErr != nil {
+		return nil, responseError(respErr)
+	}
+
+	return &pb.CaptureHoldResponse{
+		Hold:        convertHold(result.Hold),
+		Transfer:    convertTransfer(result.Transfer),
+		FromAccount: convertAccount(result.FromAccount),
+		ToAccount:   convertAccount(result.ToAccount),
+		FromEntry:   convertEntry(result.FromEntry),
+		ToEntry:     convertEntry(result.ToEntry),
+	}, nil
+}
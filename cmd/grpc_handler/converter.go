@@ -0,0 +1,199 @@
+package gapi
+
+import (
+	db "kara-bank/db/repositories"
+	"kara-bank/pb"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func convertAccount(account *db.Account) *pb.Account {
+	return &pb.Account{
+		Id:               account.ID,
+		Owner:            account.Owner,
+		Balance:          account.Balance,
+		Currency:         account.Currency,
+		CreatedAt:        timestamppb.New(account.CreatedAt),
+		OverdraftLimit:   account.OverdraftLimit,
+		HeldAmount:       account.HeldAmount,
+		AvailableBalance: account.AvailableBalance,
+	}
+}
+
+func convertTransfer(transfer *db.Transfer) *pb.Transfer {
+	result := &pb.Transfer{
+		Id:            transfer.ID,
+		FromAccountId: transfer.FromAccountID,
+		ToAccountId:   transfer.ToAccountID,
+		Amount:        transfer.Amount,
+		CreatedAt:     timestamppb.New(transfer.CreatedAt),
+		ToAmount:      transfer.ToAmount,
+		ExchangeRate:  transfer.ExchangeRate.Float64(),
+	}
+
+	if transfer.ReversalOf != nil {
+		result.ReversalOf = *transfer.ReversalOf
+	}
+
+	return result
+}
+
+func convertHold(hold *db.Hold) *pb.Hold {
+	result := &pb.Hold{
+		Id:          hold.ID,
+		AccountId:   hold.AccountID,
+		Amount:      hold.Amount,
+		Description: hold.Description,
+		Status:      hold.Status,
+		CreatedAt:   timestamppb.New(hold.CreatedAt),
+	}
+
+	if hold.TransferID != nil {
+		result.TransferId = *hold.TransferID
+	}
+
+	if hold.ExpiresAt != nil {
+		result.ExpiresAt = timestamppb.New(*hold.ExpiresAt)
+	}
+
+	if hold.ClosedAt != nil {
+		result.ClosedAt = timestamppb.New(*hold.ClosedAt)
+	}
+
+	return result
+}
+
+func convertTransferApproval(approval *db.TransferApproval) *pb.TransferApproval {
+	result := &pb.TransferApproval{
+		Id:            approval.ID,
+		FromAccountId: approval.FromAccountID,
+		ToAccountId:   approval.ToAccountID,
+		Amount:        approval.Amount,
+		Initiator:     approval.Initiator,
+		Status:        approval.Status,
+		CreatedAt:     timestamppb.New(approval.CreatedAt),
+	}
+
+	if approval.Reviewer != nil {
+		result.Reviewer = *approval.Reviewer
+	}
+
+	if approval.TransferID != nil {
+		result.TransferId = *approval.TransferID
+	}
+
+	if approval.DecidedAt != nil {
+		result.DecidedAt = timestamppb.New(*approval.DecidedAt)
+	}
+
+	if approval.HoldID != nil {
+		result.HoldId = *approval.HoldID
+	}
+
+	return result
+}
+
+func convertEntry(entry *db.Entry) *pb.Entry {
+	result := &pb.Entry{
+		Id:          entry.ID,
+		AccountId:   entry.AccountID,
+		Amount:      entry.Amount,
+		CreatedAt:   timestamppb.New(entry.CreatedAt),
+		Description: entry.Description,
+		Category:    entry.Category,
+	}
+
+	if entry.TransferID != nil {
+		result.TransferId = *entry.TransferID
+	}
+
+	if entry.JournalID != nil {
+		result.JournalId = *entry.JournalID
+	}
+
+	return result
+}
+
+func convertStandingOrder(standingOrder *db.StandingOrder) *pb.StandingOrder {
+	result := &pb.StandingOrder{
+		Id:             standingOrder.ID,
+		FromAccountId:  standingOrder.FromAccountID,
+		ToAccountId:    standingOrder.ToAccountID,
+		Amount:         standingOrder.Amount,
+		Frequency:      standingOrder.Frequency,
+		StartAt:        timestamppb.New(standingOrder.StartAt),
+		Status:         standingOrder.Status,
+		ExecutionCount: standingOrder.ExecutionCount,
+		FailedAttempts: standingOrder.FailedAttempts,
+		CreatedAt:      timestamppb.New(standingOrder.CreatedAt),
+	}
+
+	if standingOrder.EndAt != nil {
+		result.EndAt = timestamppb.New(*standingOrder.EndAt)
+	}
+
+	if standingOrder.NextExecutionAt != nil {
+		result.NextExecutionAt = timestamppb.New(*standingOrder.NextExecutionAt)
+	}
+
+	if standingOrder.LastError != nil {
+		result.LastError = *standingOrder.LastError
+	}
+
+	if standingOrder.LastTransferID != nil {
+		result.LastTransferId = *standingOrder.LastTransferID
+	}
+
+	return result
+}
+
+func convertLedgerAccount(ledgerAccount *db.LedgerAccount) *pb.LedgerAccount {
+	return &pb.LedgerAccount{
+		Code:      ledgerAccount.Code,
+		Name:      ledgerAccount.Name,
+		Type:      ledgerAccount.Type,
+		CreatedAt: timestamppb.New(ledgerAccount.CreatedAt),
+	}
+}
+
+func convertJournal(journal *db.Journal) *pb.Journal {
+	result := &pb.Journal{
+		Id:          journal.ID,
+		Description: journal.Description,
+		CreatedAt:   timestamppb.New(journal.CreatedAt),
+	}
+
+	if journal.TransferID != nil {
+		result.TransferId = *journal.TransferID
+	}
+
+	return result
+}
+
+func convertPosting(posting *db.Posting) *pb.Posting {
+	result := &pb.Posting{
+		Id:            posting.ID,
+		JournalId:     posting.JournalID,
+		LedgerAccount: posting.LedgerAccount,
+		Amount:        posting.Amount,
+		Currency:      posting.Currency,
+		CreatedAt:     timestamppb.New(posting.CreatedAt),
+	}
+
+	if posting.AccountID != nil {
+		result.AccountId = *posting.AccountID
+	}
+
+	return result
+}
+
+// convertOptionalTime returns nil for unset timestamps of optional filters
+func convertOptionalTime(timestamp *timestamppb.Timestamp) *time.Time {
+	if timestamp == nil {
+		return nil
+	}
+
+	result := timestamp.AsTime()
+	return &result
+}
@@ -0,0 +1,37 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/dto"
+	"kara-bank/pb"
+)
+
+func (s GrpcServer) CreateAccount(ctx context.Context, req *pb.CreateAccountRequest) (*pb.CreateAccountResponse, error) {
+	user, err := getAuthenticatedUser(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	args := &dto.CreateAccountDto{
+		Owner:          user.email,
+		Currency:       req.Currency,
+		IdempotencyKey: getIdempotencyKey(ctx),
+	}
+
+	err = s.validator.Struct(args)
+
+	if err != nil {
+		return nil, validationError(err)
+	}
+
+	account, respErr := s.accountService.CreateAccount(ctx, args)
+
+	if respErr != nil {
+		return nil, responseError(respErr)
+	}
+
+	return &pb.CreateAccountResponse{
+		Account: convertAccount(account),
+	}, nil
+}
@@ -0,0 +1,32 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/dto"
+	"kara-bank/pb"
+)
+
+func (s GrpcServer) CreateHold(ctx context.Context, req *pb.CreateHoldRequest) (*pb.CreateHoldResponse, error) {
+	args := &dto.CreateHoldDto{
+		AccountId:   req.AccountId,
+		Amount:      req.Amount,
+		Description: req.Description,
+		ExpiresAt:   convertOptionalTime(req.ExpiresAt),
+	}
+
+	err := s.validator.Struct(args)
+
+	if err != nil {
+		return nil, validationError(err)
+	}
+
+	hold, respErr := s.holdService.CreateHold(ctx, args)
+
+	if respErr != nil {
+		return nil, responseError(respErr)
+	}
+
+	return &pb.CreateHoldResponse{
+		Hold: convertHold(hold),
+	}, nil
+}
@@ -0,0 +1,31 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/dto"
+	"kara-bank/pb"
+)
+
+func (s GrpcServer) CreateLedgerAccount(ctx context.Context, req *pb.CreateLedgerAccountRequest) (*pb.CreateLedgerAccountResponse, error) {
+	args := &dto.CreateLedgerAccountDto{
+		Code: req.Code,
+		Name: req.Name,
+		Type: req.Type,
+	}
+
+	err := s.validator.Struct(args)
+
+	if err != nil {
+		return nil, validationError(err)
+	}
+
+	ledgerAccount, respErr := s.ledgerService.CreateLedgerAccount(ctx, args)
+
+	if respErr != nil {
+		return nil, responseError(respErr)
+	}
+
+	return &pb.CreateLedgerAccountResponse{
+		LedgerAccount: convertLedgerAccount(ledgerAccount),
+	}, nil
+}
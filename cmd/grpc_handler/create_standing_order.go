@@ -0,0 +1,41 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/dto"
+	"kara-bank/pb"
+)
+
+func (s GrpcServer) CreateStandingOrder(ctx context.Context, req *pb.CreateStandingOrderRequest) (*pb.CreateStandingOrderResponse, error) {
+	user, err := getAuthenticatedUser(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	args := &dto.CreateStandingOrderDto{
+		Owner:         user.email,
+		FromAccountId: req.FromAccountId,
+		ToAccountId:   req.ToAccountId,
+		Amount:        req.Amount,
+		Frequency:     req.Frequency,
+		StartAt:       convertOptionalTime(req.StartAt),
+		EndAt:         convertOptionalTime(req.EndAt),
+	}
+
+	err = s.validator.Struct(args)
+
+	if err != nil {
+		return nil, validationError(err)
+	}
+
+	standingOrder, respErr := s.standingOrderService.CreateStandingOrder(ctx, args)
+
+	if respErr != nil {
+		return nil, responseError(respErr)
+	}
+
+	return &pb.CreateStandingOrderResponse{
+		StandingOrder: convertStandingOrder(standingOrder),
+	}, nil
+}
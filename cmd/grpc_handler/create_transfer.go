@@ -0,0 +1,49 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/dto"
+	"kara-bank/pb"
+)
+
+func (s GrpcServer) CreateTransfer(ctx context.Context, req *pb.CreateTransferRequest) (*pb.CreateTransferResponse, error) {
+	user, err := getAuthenticatedUser(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	args := &dto.CreateTransferDto{
+		FromUser:       user.email,
+		FromAccountId:  req.FromAccountId,
+		ToAccountId:    req.ToAccountId,
+		Amount:         req.Amount,
+		IdempotencyKey: getIdempotencyKey(ctx),
+	}
+
+	err = s.validator.Struct(args)
+
+	if err != nil {
+		return nil, validationError(err)
+	}
+
+	result, respErr := s.transerService.CreateTransfer(ctx, args)
+
+	if respErr != nil {
+		return nil, responseError(respErr)
+	}
+
+	if result.Approval != nil {
+		return &pb.CreateTransferResponse{
+			Approval: convertTransferApproval(result.Approval),
+		}, nil
+	}
+
+	return &pb.CreateTransferResponse{
+		Transfer:    convertTransfer(result.Transfer),
+		FromAccount: convertAccount(result.FromAccount),
+		ToAccount:   convertAccount(result.ToAccount),
+		FromEntry:   convertEntry(result.FromEntry),
+		ToEntry:     convertEntry(result.ToEntry),
+	}, nil
+}
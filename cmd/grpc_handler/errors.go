@@ -0,0 +1,135 @@
+package gapi
+
+import (
+	"errors"
+	"kara-bank/dto"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const errorDomain = "kara-bank"
+
+const reasonValidationFailed = "VALIDATION_FAILED"
+
+// httpStatusCodes translates the http status codes used by dto.ResponseError into grpc codes
+var httpStatusCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.AlreadyExists,
+	http.StatusPreconditionFailed:  codes.FailedPrecondition,
+	http.StatusUnprocessableEntity: codes.FailedPrecondition,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	http.StatusInternalServerError: codes.Internal,
+	http.StatusNotImplemented:      codes.Unimplemented,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+	http.StatusGatewayTimeout:      codes.DeadlineExceeded,
+}
+
+// responseError converts a service error into a grpc status error. The details carry the reason of domain errors,
+// e.g. INSUFFICIENT_FUNDS, or a reason derived from the http status so clients can tell e.g. a missing user apart
+// from a duplicate email.
+func responseError(respErr *dto.ResponseError) error {
+	code, ok := httpStatusCodes[respErr.Status]
+
+	if !ok {
+		code = codes.Unknown
+	}
+
+	st := status.New(code, respErr.Message)
+	reason := respErr.Reason
+
+	if reason == "" {
+		reason = reasonFromHttpStatus(respErr.Status)
+	}
+
+	errorInfo := &errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: errorDomain,
+		Metadata: map[string]string{
+			"http_status": strconv.Itoa(respErr.Status),
+		},
+	}
+
+	detailed, err := st.WithDetails(errorInfo)
+
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
+// validationError converts a validator error into an InvalidArgument status with one field violation per failed field
+func validationError(err error) error {
+	var validationErrors validator.ValidationErrors
+
+	if !errors.As(err, &validationErrors) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	badRequest := &errdetails.BadRequest{}
+
+	for _, fieldError := range validationErrors {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       toSnakeCase(fieldError.StructField()),
+			Description: fieldError.Error(),
+		})
+	}
+
+	errorInfo := &errdetails.ErrorInfo{
+		Reason: reasonValidationFailed,
+		Domain: errorDomain,
+	}
+
+	st := status.New(codes.InvalidArgument, "invalid request parameters")
+	detailed, detailErr := st.WithDetails(badRequest, errorInfo)
+
+	if detailErr != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
+// reasonFromHttpStatus turns e.g. 404 into NOT_FOUND
+func reasonFromHttpStatus(httpStatus int) string {
+	text := http.StatusText(httpStatus)
+
+	if text == "" {
+		return "UNKNOWN"
+	}
+
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, text)
+}
+
+// toSnakeCase turns go field names like FromAccountId into the proto field name from_account_id
+func toSnakeCase(name string) string {
+	var builder strings.Builder
+	runes := []rune(name)
+
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])) {
+				builder.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		builder.WriteRune(r)
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,114 @@
+package gapi
+
+import (
+	"errors"
+	"kara-bank/dto"
+	"net/http"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestResponseErrorCodes(t *testing.T) {
+	testCases := []struct {
+		httpStatus int
+		code       codes.Code
+		reason     string
+	}{
+		{http.StatusBadRequest, codes.InvalidArgument, "BAD_REQUEST"},
+		{http.StatusUnauthorized, codes.Unauthenticated, "UNAUTHORIZED"},
+		{http.StatusNotFound, codes.NotFound, "NOT_FOUND"},
+		{http.StatusConflict, codes.AlreadyExists, "CONFLICT"},
+		{http.StatusUnprocessableEntity, codes.FailedPrecondition, "UNPROCESSABLE_ENTITY"},
+		{http.StatusInternalServerError, codes.Internal, "INTERNAL_SERVER_ERROR"},
+		{http.StatusTeapot, codes.Unknown, "I_M_A_TEAPOT"},
+	}
+
+	for _, tc := range testCases {
+		err := responseError(&dto.ResponseError{
+			Message: "something went wrong",
+			Status:  tc.httpStatus,
+		})
+
+		st, ok := status.FromError(err)
+		require.True(t, ok)
+		require.Equal(t, tc.code, st.Code())
+		require.Equal(t, "something went wrong", st.Message())
+		require.Len(t, st.Details(), 1)
+
+		errorInfo, ok := st.Details()[0].(*errdetails.ErrorInfo)
+		require.True(t, ok)
+		require.Equal(t, tc.reason, errorInfo.Reason)
+		require.Equal(t, errorDomain, errorInfo.Domain)
+	}
+}
+
+func TestResponseErrorDomainReasons(t *testing.T) {
+	testCases := []struct {
+		respErr *dto.ResponseError
+		code    codes.Code
+		reason  string
+	}{
+		{
+			respErr: &dto.ResponseError{Message: "insufficient funds", Status: http.StatusUnprocessableEntity, Reason: dto.ReasonInsufficientFunds},
+			code:    codes.FailedPrecondition,
+			reason:  "INSUFFICIENT_FUNDS",
+		},
+		{
+			respErr: &dto.ResponseError{Message: "accounts have different currencies", Status: http.StatusUnprocessableEntity, Reason: dto.ReasonCurrencyMismatch},
+			code:    codes.FailedPrecondition,
+			reason:  "CURRENCY_MISMATCH",
+		},
+		{
+			respErr: &dto.ResponseError{Message: "Only active standing orders can be changed", Status: http.StatusConflict, Reason: dto.ReasonStandingOrderNotActive},
+			code:    codes.AlreadyExists,
+			reason:  "STANDING_ORDER_NOT_ACTIVE",
+		},
+	}
+
+	for _, tc := range testCases {
+		st, ok := status.FromError(responseError(tc.respErr))
+		require.True(t, ok)
+		require.Equal(t, tc.code, st.Code())
+		require.Len(t, st.Details(), 1)
+
+		errorInfo, ok := st.Details()[0].(*errdetails.ErrorInfo)
+		require.True(t, ok)
+		require.Equal(t, tc.reason, errorInfo.Reason)
+	}
+}
+
+func TestValidationErrorFieldViolations(t *testing.T) {
+	err := validator.New(validator.WithRequiredStructEnabled()).Struct(&dto.CreateTransferDto{
+		FromUser:      "Max@Mustermann.de",
+		FromAccountId: 0,
+		ToAccountId:   2,
+		Amount:        -1,
+	})
+	require.Error(t, err)
+
+	st, ok := status.FromError(validationError(err))
+	require.True(t, ok)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+
+	var fields []string
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.FieldViolations {
+				fields = append(fields, violation.Field)
+			}
+		}
+	}
+	require.Equal(t, []string{"from_account_id", "amount"}, fields)
+}
+
+func TestValidationErrorWithoutValidator(t *testing.T) {
+	st, ok := status.FromError(validationError(errors.New("invalid input")))
+	require.True(t, ok)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+	require.Equal(t, "invalid input", st.Message())
+}
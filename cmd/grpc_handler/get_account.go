@@ -0,0 +1,24 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/pb"
+)
+
+func (s GrpcServer) GetAccount(ctx context.Context, req *pb.GetAccountRequest) (*pb.GetAccountResponse, error) {
+	user, err := getAuthenticatedUser(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	account, respErr := s.accountService.GetAccount(ctx, req.Id, user.email, user.role)
+
+	if respErr != nil {
+		return nil, responseError(respErr)
+	}
+
+	return &pb.GetAccountResponse{
+		Account: convertAccount(account),
+	}, nil
+}
@@ -0,0 +1,45 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/dto"
+	"kara-bank/pb"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s GrpcServer) GetAccountBalance(ctx context.Context, req *pb.GetAccountBalanceRequest) (*pb.GetAccountBalanceResponse, error) {
+	user, err := getAuthenticatedUser(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	args := &dto.GetAccountBalanceDto{
+		AccountId: req.AccountId,
+	}
+
+	// a missing timestamp stays zero and fails the validation
+	if req.At != nil {
+		args.At = req.At.AsTime()
+	}
+
+	err = s.validator.Struct(args)
+
+	if err != nil {
+		return nil, validationError(err)
+	}
+
+	balance, respErr := s.accountService.GetAccountBalance(ctx, args, user.email, user.role)
+
+	if respErr != nil {
+		return nil, responseError(respErr)
+	}
+
+	return &pb.GetAccountBalanceResponse{
+		AccountId: balance.AccountId,
+		Currency:  balance.Currency,
+		Balance:   balance.Balance,
+		At:        timestamppb.New(balance.At),
+	}, nil
+}
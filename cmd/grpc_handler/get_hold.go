@@ -0,0 +1,24 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/pb"
+)
+
+func (s GrpcServer) GetHold(ctx context.Context, req *pb.GetHoldRequest) (*pb.GetHoldResponse, error) {
+	user, err := getAuthenticatedUser(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	hold, respErr := s.holdService.GetHold(ctx, req.Id, user.email, user.role)
+
+	if respErr != nil {
+		return nil, responseError(respErr)
+	}
+
+	return &pb.GetHoldResponse{
+		Hold: convertHold(hold),
+	}, nil
+}
@@ -0,0 +1,25 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/pb"
+)
+
+func (s GrpcServer) GetJournal(ctx context.Context, req *pb.GetJournalRequest) (*pb.GetJournalResponse, error) {
+	result, respErr := s.ledgerService.GetJournal(ctx, req.Id)
+
+	if respErr != nil {
+		return nil, responseError(respErr)
+	}
+
+	response := &pb.GetJournalResponse{
+		Journal:  convertJournal(result.Journal),
+		Postings: make([]*pb.Posting, 0, len(result.Postings)),
+	}
+
+	for _, posting := range result.Postings {
+		response.Postings = append(response.Postings, convertPosting(posting))
+	}
+
+	return response, nil
+}
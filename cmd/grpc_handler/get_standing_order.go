@@ -0,0 +1,24 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/pb"
+)
+
+func (s GrpcServer) GetStandingOrder(ctx context.Context, req *pb.GetStandingOrderRequest) (*pb.GetStandingOrderResponse, error) {
+	user, err := getAuthenticatedUser(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	standingOrder, respErr := s.standingOrderService.GetStandingOrder(ctx, req.Id, user.email, user.role)
+
+	if respErr != nil {
+		return nil, responseError(respErr)
+	}
+
+	return &pb.GetStandingOrderResponse{
+		StandingOrder: convertStandingOrder(standingOrder),
+	}, nil
+}
@@ -0,0 +1,24 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/pb"
+)
+
+func (s GrpcServer) GetTransfer(ctx context.Context, req *pb.GetTransferRequest) (*pb.GetTransferResponse, error) {
+	user, err := getAuthenticatedUser(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	transfer, respErr := s.transerService.GetTransfer(ctx, req.Id, user.email, user.role)
+
+	if respErr != nil {
+		return nil, responseError(respErr)
+	}
+
+	return &pb.GetTransferResponse{
+		Transfer: convertTransfer(transfer),
+	}, nil
+}
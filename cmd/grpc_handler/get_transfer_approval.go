@@ -0,0 +1,24 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/pb"
+)
+
+func (s GrpcServer) GetTransferApproval(ctx context.Context, req *pb.GetTransferApprovalRequest) (*pb.GetTransferApprovalResponse, error) {
+	user, err := getAuthenticatedUser(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	approval, respErr := s.transerService.GetTransferApproval(ctx, req.Id, user.email, user.role)
+
+	if respErr != nil {
+		return nil, responseError(respErr)
+	}
+
+	return &pb.GetTransferApprovalResponse{
+		Approval: convertTransferApproval(approval),
+	}, nil
+}
@@ -0,0 +1,31 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/pb"
+)
+
+func (s GrpcServer) GetTrialBalance(ctx context.Context, req *pb.GetTrialBalanceRequest) (*pb.GetTrialBalanceResponse, error) {
+	trialBalance, respErr := s.ledgerService.GetTrialBalance(ctx, convertOptionalTime(req.EndTime))
+
+	if respErr != nil {
+		return nil, responseError(respErr)
+	}
+
+	response := &pb.GetTrialBalanceResponse{
+		Items: make([]*pb.TrialBalanceRow, 0, len(trialBalance)),
+	}
+
+	for _, row := range trialBalance {
+		response.Items = append(response.Items, &pb.TrialBalanceRow{
+			LedgerAccount: row.LedgerAccount,
+			Name:          row.Name,
+			Type:          row.Type,
+			Currency:      row.Currency,
+			Debit:         row.Debit,
+			Credit:        row.Credit,
+		})
+	}
+
+	return response, nil
+}
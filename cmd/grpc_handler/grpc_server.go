@@ -3,23 +3,36 @@ package gapi
 import (
 	"kara-bank/pb"
 	"kara-bank/services"
+
+	"github.com/go-playground/validator/v10"
 )
 
 type GrpcServer struct {
 	pb.UnimplementedKaraBankServer
-	userService    services.UserServiceInterface
-	accountService services.AccountServiceInterface
-	transerService services.TransferServiceInterface
+	userService          services.UserServiceInterface
+	accountService       services.AccountServiceInterface
+	transerService       services.TransferServiceInterface
+	standingOrderService services.StandingOrderServiceInterface
+	holdService          services.HoldServiceInterface
+	ledgerService        services.LedgerServiceInterface
+	validator            *validator.Validate
 }
 
 func InitGrpcHandler(
 	userService services.UserServiceInterface,
 	accountService services.AccountServiceInterface,
 	transferService services.TransferServiceInterface,
+	standingOrderService services.StandingOrderServiceInterface,
+	holdService services.HoldServiceInterface,
+	ledgerService services.LedgerServiceInterface,
 ) *GrpcServer {
 	return &GrpcServer{
-		userService:    userService,
-		accountService: accountService,
-		transerService: transferService,
+		userService:          userService,
+		accountService:       accountService,
+		transerService:       transferService,
+		standingOrderService: standingOrderService,
+		holdService:          holdService,
+		ledgerService:        ledgerService,
+		validator:            validator.New(validator.WithRequiredStructEnabled()),
 	}
 }
@@ -0,0 +1,48 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/dto"
+	"kara-bank/pb"
+)
+
+func (s GrpcServer) ListEntries(ctx context.Context, req *pb.ListEntriesRequest) (*pb.ListEntriesResponse, error) {
+	user, err := getAuthenticatedUser(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	args := &dto.ListEntriesDto{
+		AccountId: req.AccountId,
+		Limit:     dto.PageSize(req.Limit),
+		Cursor:    req.Cursor,
+		StartTime: convertOptionalTime(req.StartTime),
+		EndTime:   convertOptionalTime(req.EndTime),
+		Direction: req.Direction,
+	}
+
+	err = s.validator.Struct(args)
+
+	if err != nil {
+		return nil, validationError(err)
+	}
+
+	page, respErr := s.accountService.ListEntries(ctx, args, user.email, user.role)
+
+	if respErr != nil {
+		return nil, responseError(respErr)
+	}
+
+	response := &pb.ListEntriesResponse{
+		Items:      make([]*pb.Entry, 0, len(page.Items)),
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+	}
+
+	for _, entry := range page.Items {
+		response.Items = append(response.Items, convertEntry(entry))
+	}
+
+	return response, nil
+}
@@ -0,0 +1,45 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/dto"
+	"kara-bank/pb"
+)
+
+func (s GrpcServer) ListHolds(ctx context.Context, req *pb.ListHoldsRequest) (*pb.ListHoldsResponse, error) {
+	user, err := getAuthenticatedUser(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	args := &dto.ListHoldsDto{
+		AccountId: req.AccountId,
+		Limit:     dto.PageSize(req.Limit),
+		Cursor:    req.Cursor,
+	}
+
+	err = s.validator.Struct(args)
+
+	if err != nil {
+		return nil, validationError(err)
+	}
+
+	page, respErr := s.holdService.ListHolds(ctx, args, user.email, user.role)
+
+	if respErr != nil {
+		return nil, responseError(respErr)
+	}
+
+	response := &pb.ListHoldsResponse{
+		Items:      make([]*pb.Hold, 0, len(page.Items)),
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+	}
+
+	for _, hold := range page.Items {
+		response.Items = append(response.Items, convertHold(hold))
+	}
+
+	return response, nil
+}
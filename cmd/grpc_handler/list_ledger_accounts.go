@@ -0,0 +1,24 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/pb"
+)
+
+func (s GrpcServer) ListLedgerAccounts(ctx context.Context, req *pb.ListLedgerAccountsRequest) (*pb.ListLedgerAccountsResponse, error) {
+	ledgerAccounts, respErr := s.ledgerService.ListLedgerAccounts(ctx)
+
+	if respErr != nil {
+		return nil, responseError(respErr)
+	}
+
+	response := &pb.ListLedgerAccountsResponse{
+		Items: make([]*pb.LedgerAccount, 0, len(ledgerAccounts)),
+	}
+
+	for _, ledgerAccount := range ledgerAccounts {
+		response.Items = append(response.Items, convertLedgerAccount(ledgerAccount))
+	}
+
+	return response, nil
+}
@@ -0,0 +1,44 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/dto"
+	"kara-bank/pb"
+)
+
+func (s GrpcServer) ListOwnAccounts(ctx context.Context, req *pb.ListOwnAccountsRequest) (*pb.ListOwnAccountsResponse, error) {
+	user, err := getAuthenticatedUser(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	args := &dto.ListAccountsDto{
+		Limit:  dto.PageSize(req.Limit),
+		Cursor: req.Cursor,
+	}
+
+	err = s.validator.Struct(args)
+
+	if err != nil {
+		return nil, validationError(err)
+	}
+
+	page, respErr := s.accountService.ListOwnAccounts(ctx, args, user.email)
+
+	if respErr != nil {
+		return nil, responseError(respErr)
+	}
+
+	response := &pb.ListOwnAccountsResponse{
+		Items:      make([]*pb.Account, 0, len(page.Items)),
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+	}
+
+	for _, account := range page.Items {
+		response.Items = append(response.Items, convertAccount(account))
+	}
+
+	return response, nil
+}
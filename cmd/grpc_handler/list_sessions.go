@@ -0,0 +1,39 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/pb"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s GrpcServer) ListSessions(ctx context.Context, req *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	user, err := getAuthenticatedUser(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, respErr := s.userService.ListSessions(ctx, user.email, user.sessionId)
+
+	if respErr != nil {
+		return nil, responseError(respErr)
+	}
+
+	response := &pb.ListSessionsResponse{
+		Sessions: make([]*pb.Session, 0, len(sessions)),
+	}
+
+	for _, session := range sessions {
+		response.Sessions = append(response.Sessions, &pb.Session{
+			Id:        session.ID.String(),
+			UserAgent: session.UserAgent,
+			ClientIp:  session.ClientIp,
+			Current:   session.Current,
+			CreatedAt: timestamppb.New(session.CreatedAt),
+			ExpiresAt: timestamppb.New(session.ExpiresAt),
+		})
+	}
+
+	return response, nil
+}
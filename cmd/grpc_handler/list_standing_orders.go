@@ -0,0 +1,44 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/dto"
+	"kara-bank/pb"
+)
+
+func (s GrpcServer) ListStandingOrders(ctx context.Context, req *pb.ListStandingOrdersRequest) (*pb.ListStandingOrdersResponse, error) {
+	user, err := getAuthenticatedUser(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	args := &dto.ListStandingOrdersDto{
+		Limit:  dto.PageSize(req.Limit),
+		Cursor: req.Cursor,
+	}
+
+	err = s.validator.Struct(args)
+
+	if err != nil {
+		return nil, validationError(err)
+	}
+
+	page, respErr := s.standingOrderService.ListStandingOrders(ctx, args, user.email)
+
+	if respErr != nil {
+		return nil, responseError(respErr)
+	}
+
+	response := &pb.ListStandingOrdersResponse{
+		Items:      make([]*pb.StandingOrder, 0, len(page.Items)),
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+	}
+
+	for _, standingOrder := range page.Items {
+		response.Items = append(response.Items, convertStandingOrder(standingOrder))
+	}
+
+	return response, nil
+}
@@ -0,0 +1,38 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/dto"
+	"kara-bank/pb"
+)
+
+func (s GrpcServer) ListTransferApprovals(ctx context.Context, req *pb.ListTransferApprovalsRequest) (*pb.ListTransferApprovalsResponse, error) {
+	args := &dto.ListTransferApprovalsDto{
+		Limit:  dto.PageSize(req.Limit),
+		Cursor: req.Cursor,
+	}
+
+	err := s.validator.Struct(args)
+
+	if err != nil {
+		return nil, validationError(err)
+	}
+
+	page, respErr := s.transerService.ListPendingTransferApprovals(ctx, args)
+
+	if respErr != nil {
+		return nil, responseError(respErr)
+	}
+
+	response := &pb.ListTransferApprovalsResponse{
+		Items:      make([]*pb.TransferApproval, 0, len(page.Items)),
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+	}
+
+	for _, approval := range page.Items {
+		response.Items = append(response.Items, convertTransferApproval(approval))
+	}
+
+	return response, nil
+}
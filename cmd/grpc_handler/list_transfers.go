@@ -0,0 +1,48 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/dto"
+	"kara-bank/pb"
+)
+
+func (s GrpcServer) ListTransfers(ctx context.Context, req *pb.ListTransfersRequest) (*pb.ListTransfersResponse, error) {
+	user, err := getAuthenticatedUser(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	args := &dto.ListTransfersDto{
+		AccountId: req.AccountId,
+		Limit:     dto.PageSize(req.Limit),
+		Cursor:    req.Cursor,
+		StartTime: convertOptionalTime(req.StartTime),
+		EndTime:   convertOptionalTime(req.EndTime),
+		Direction: req.Direction,
+	}
+
+	err = s.validator.Struct(args)
+
+	if err != nil {
+		return nil, validationError(err)
+	}
+
+	page, respErr := s.transerService.ListTransfers(ctx, args, user.email, user.role)
+
+	if respErr != nil {
+		return nil, responseError(respErr)
+	}
+
+	response := &pb.ListTransfersResponse{
+		Items:      make([]*pb.Transfer, 0, len(page.Items)),
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+	}
+
+	for _, transfer := range page.Items {
+		response.Items = append(response.Items, convertTransfer(transfer))
+	}
+
+	return response, nil
+}
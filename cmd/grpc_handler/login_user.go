@@ -2,24 +2,38 @@ package gapi
 
 import (
 	"context"
-	"errors"
 	"kara-bank/dto"
 	"kara-bank/pb"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func (s GrpcServer) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (*pb.LoginUserResponse, error) {
-	token, respErr := s.userService.LoginUser(ctx, &dto.LoginUserDto{
+	mtdt := extractMetadata(ctx)
+
+	args := &dto.LoginUserDto{
 		Email:     req.Email,
 		Password:  req.Password,
-		UserAgent: "",
-		ClientIp:  "",
-	})
+		UserAgent: mtdt.userAgent,
+		ClientIp:  mtdt.clientIp,
+	}
+
+	err := s.validator.Struct(args)
+
+	if err != nil {
+		return nil, validationError(err)
+	}
+
+	result, respErr := s.userService.LoginUser(ctx, args)
 
 	if respErr != nil {
-		return nil, errors.New(respErr.Message)
+		return nil, responseError(respErr)
 	}
 
 	return &pb.LoginUserResponse{
-		Token: token,
+		Token:                 result.AccessToken,
+		TokenExpiresAt:        timestamppb.New(result.AccessTokenExpiresAt),
+		RefreshToken:          result.RefreshToken,
+		RefreshTokenExpiresAt: timestamppb.New(result.RefreshTokenExpiresAt),
 	}, nil
 }
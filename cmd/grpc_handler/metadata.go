@@ -0,0 +1,78 @@
+package gapi
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	grpcGatewayUserAgentHeader = "grpcgateway-user-agent"
+	userAgentHeader            = "user-agent"
+	xForwardedForHeader        = "x-forwarded-for"
+	idempotencyKeyHeader       = "idempotency-key"
+)
+
+type requestMetadata struct {
+	userAgent string
+	clientIp  string
+}
+
+// extractMetadata reads user agent and client ip of the caller. Requests coming through the grpc gateway carry
+// the values of the original http request, direct grpc calls use the metadata and peer of the connection.
+func extractMetadata(ctx context.Context) *requestMetadata {
+	result := &requestMetadata{}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+
+	if ok {
+		if userAgents := md.Get(grpcGatewayUserAgentHeader); len(userAgents) > 0 {
+			result.userAgent = userAgents[0]
+		} else if userAgents := md.Get(userAgentHeader); len(userAgents) > 0 {
+			result.userAgent = userAgents[0]
+		}
+	}
+
+	p, ok := peer.FromContext(ctx)
+
+	if !ok {
+		return result
+	}
+
+	peerIp := p.Addr.String()
+
+	if host, _, err := net.SplitHostPort(peerIp); err == nil {
+		peerIp = host
+	}
+
+	result.clientIp = peerIp
+
+	// only the gateway, which runs on the same host, may forward the client ip. The gateway appends the address
+	// of the http client to the header, so the last value is the one it has seen itself.
+	if ip := net.ParseIP(peerIp); ip != nil && ip.IsLoopback() {
+		if forwardedFor := md.Get(xForwardedForHeader); len(forwardedFor) > 0 {
+			addresses := strings.Split(forwardedFor[len(forwardedFor)-1], ",")
+			result.clientIp = strings.TrimSpace(addresses[len(addresses)-1])
+		}
+	}
+
+	return result
+}
+
+// getIdempotencyKey returns the optional idempotency key of the request
+func getIdempotencyKey(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+
+	if !ok {
+		return ""
+	}
+
+	if keys := md.Get(idempotencyKeyHeader); len(keys) > 0 {
+		return keys[0]
+	}
+
+	return ""
+}
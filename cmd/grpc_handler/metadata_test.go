@@ -0,0 +1,50 @@
+package gapi
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func contextWithPeer(address string, md metadata.MD) context.Context {
+	addr, _ := net.ResolveTCPAddr("tcp", address)
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+
+	return metadata.NewIncomingContext(ctx, md)
+}
+
+func TestExtractMetadataDirectCall(t *testing.T) {
+	ctx := contextWithPeer("203.0.113.7:52000", metadata.Pairs(
+		userAgentHeader, "grpc-go/1.68.0",
+		xForwardedForHeader, "198.51.100.1",
+	))
+
+	mtdt := extractMetadata(ctx)
+	require.Equal(t, "grpc-go/1.68.0", mtdt.userAgent)
+	// direct callers cannot spoof their address with x-forwarded-for
+	require.Equal(t, "203.0.113.7", mtdt.clientIp)
+}
+
+func TestExtractMetadataThroughGateway(t *testing.T) {
+	ctx := contextWithPeer("127.0.0.1:52000", metadata.Pairs(
+		userAgentHeader, "grpc-go/1.68.0",
+		grpcGatewayUserAgentHeader, "Mozilla/5.0",
+		xForwardedForHeader, "198.51.100.1, 203.0.113.7",
+	))
+
+	mtdt := extractMetadata(ctx)
+	require.Equal(t, "Mozilla/5.0", mtdt.userAgent)
+	require.Equal(t, "203.0.113.7", mtdt.clientIp)
+}
+
+func TestExtractMetadataWithoutPeer(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(userAgentHeader, "grpc-go/1.68.0"))
+
+	mtdt := extractMetadata(ctx)
+	require.Equal(t, "grpc-go/1.68.0", mtdt.userAgent)
+	require.Empty(t, mtdt.clientIp)
+}
@@ -0,0 +1,52 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/dto"
+	"kara-bank/pb"
+)
+
+func (s GrpcServer) PostJournal(ctx context.Context, req *pb.PostJournalRequest) (*pb.PostJournalResponse, error) {
+	args := &dto.PostJournalDto{
+		Description: req.Description,
+		Category:    req.Category,
+	}
+
+	for _, posting := range req.Postings {
+		journalPosting := dto.JournalPosting{
+			LedgerAccount: posting.LedgerAccount,
+			Amount:        posting.Amount,
+			Currency:      posting.Currency,
+		}
+
+		// account_id is not set for postings on bank-side ledger accounts
+		if posting.AccountId != 0 {
+			journalPosting.AccountId = &posting.AccountId
+		}
+
+		args.Postings = append(args.Postings, journalPosting)
+	}
+
+	err := s.validator.Struct(args)
+
+	if err != nil {
+		return nil, validationError(err)
+	}
+
+	result, respErr := s.ledgerService.PostJournal(ctx, args)
+
+	if respErr != nil {
+		return nil, responseError(respErr)
+	}
+
+	response := &pb.PostJournalResponse{
+		Journal:  convertJournal(result.Journal),
+		Postings: make([]*pb.Posting, 0, len(result.Postings)),
+	}
+
+	for _, posting := range result.Postings {
+		response.Postings = append(response.Postings, convertPosting(posting))
+	}
+
+	return response, nil
+}
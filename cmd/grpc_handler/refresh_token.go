@@ -0,0 +1,30 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/dto"
+	"kara-bank/pb"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s GrpcServer) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
+	err := s.validator.Struct(&dto.RefreshTokenDto{
+		RefreshToken: req.RefreshToken,
+	})
+
+	if err != nil {
+		return nil, validationError(err)
+	}
+
+	result, respErr := s.userService.RefreshAccessToken(ctx, req.RefreshToken)
+
+	if respErr != nil {
+		return nil, responseError(respErr)
+	}
+
+	return &pb.RefreshTokenResponse{
+		Token:          result.AccessToken,
+		TokenExpiresAt: timestamppb.New(result.AccessTokenExpiresAt),
+	}, nil
+}
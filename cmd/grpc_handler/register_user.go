@@ -2,7 +2,6 @@ package gapi
 
 import (
 	"context"
-	"errors"
 	"kara-bank/dto"
 	"kara-bank/pb"
 
@@ -10,15 +9,23 @@ import (
 )
 
 func (s GrpcServer) RegisterUser(ctx context.Context, req *pb.RegisterUserRequest) (*pb.RegisterUserResponse, error) {
-	user, respErr := s.userService.RegisterUser(ctx, &dto.RegisterUserDto{
+	args := &dto.RegisterUserDto{
 		Email:     req.Email,
 		Password:  req.Password,
 		FirstName: req.FirstName,
 		LastName:  req.LastName,
-	})
+	}
+
+	err := s.validator.Struct(args)
+
+	if err != nil {
+		return nil, validationError(err)
+	}
+
+	user, respErr := s.userService.RegisterUser(ctx, args)
 
 	if respErr != nil {
-		return nil, errors.New(respErr.Message)
+		return nil, responseError(respErr)
 	}
 
 	return &pb.RegisterUserResponse{
@@ -0,0 +1,24 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/pb"
+)
+
+func (s GrpcServer) RejectTransfer(ctx context.Context, req *pb.RejectTransferRequest) (*pb.RejectTransferResponse, error) {
+	user, err := getAuthenticatedUser(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	approval, respErr := s.transerService.RejectTransfer(ctx, req.Id, user.email)
+
+	if respErr != nil {
+		return nil, responseError(respErr)
+	}
+
+	return &pb.RejectTransferResponse{
+		Approval: convertTransferApproval(approval),
+	}, nil
+}
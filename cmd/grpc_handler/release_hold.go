@@ -0,0 +1,18 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/pb"
+)
+
+func (s GrpcServer) ReleaseHold(ctx context.Context, req *pb.ReleaseHoldRequest) (*pb.ReleaseHoldResponse, error) {
+	hold, respErr := s.holdService.ReleaseHold(ctx, req.Id)
+
+	if respErr != nil {
+		return nil, responseError(respErr)
+	}
+
+	return &pb.ReleaseHoldResponse{
+		Hold: convertHold(hold),
+	}, nil
+}
@@ -0,0 +1,32 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/pb"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s GrpcServer) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.RevokeSessionResponse, error) {
+	user, err := getAuthenticatedUser(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sessionId, err := uuid.Parse(req.Id)
+
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	respErr := s.userService.RevokeSession(ctx, sessionId, user.email)
+
+	if respErr != nil {
+		return nil, responseError(respErr)
+	}
+
+	return &pb.RevokeSessionResponse{}, nil
+}
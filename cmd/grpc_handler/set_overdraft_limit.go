@@ -0,0 +1,36 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/dto"
+	"kara-bank/pb"
+)
+
+func (s GrpcServer) SetOverdraftLimit(ctx context.Context, req *pb.SetOverdraftLimitRequest) (*pb.SetOverdraftLimitResponse, error) {
+	user, err := getAuthenticatedUser(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	args := &dto.SetOverdraftLimitDto{
+		AccountId:      req.AccountId,
+		OverdraftLimit: req.OverdraftLimit,
+	}
+
+	err = s.validator.Struct(args)
+
+	if err != nil {
+		return nil, validationError(err)
+	}
+
+	account, respErr := s.accountService.SetOverdraftLimit(ctx, args, user.role)
+
+	if respErr != nil {
+		return nil, responseError(respErr)
+	}
+
+	return &pb.SetOverdraftLimitResponse{
+		Account: convertAccount(account),
+	}, nil
+}
@@ -0,0 +1,38 @@
+package gapi
+
+import (
+	"context"
+	"kara-bank/dto"
+	"kara-bank/pb"
+)
+
+func (s GrpcServer) UpdateStandingOrder(ctx context.Context, req *pb.UpdateStandingOrderRequest) (*pb.UpdateStandingOrderResponse, error) {
+	user, err := getAuthenticatedUser(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	args := &dto.UpdateStandingOrderDto{
+		Id:     req.Id,
+		Owner:  user.email,
+		Amount: req.Amount,
+		EndAt:  convertOptionalTime(req.EndAt),
+	}
+
+	err = s.validator.Struct(args)
+
+	if err != nil {
+		return nil, validationError(err)
+	}
+
+	standingOrder, respErr := s.standingOrderService.UpdateStandingOrder(ctx, args)
+
+	if respErr != nil {
+		return nil, responseError(respErr)
+	}
+
+	return &pb.UpdateStandingOrderResponse{
+		StandingOrder: convertStandingOrder(standingOrder),
+	}, nil
+}
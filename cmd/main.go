@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	dbserver "kara-bank/db"
 	db "kara-bank/db/repositories"
 	gapi "kara-bank/grpc_handler"
@@ -9,24 +11,52 @@ import (
 	"kara-bank/server"
 	"kara-bank/services"
 	"kara-bank/utils"
+	"kara-bank/worker"
 	"log"
 	"net"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
+// gatewayMaxBodyBytes limits the size of request bodies accepted by the grpc gateway
+const gatewayMaxBodyBytes = 1 << 20
+
 func main() {
+	if len(os.Args) > 1 {
+		runCommand(os.Args[1], os.Args[2:])
+		return
+	}
+
 	log.Println("Starting kara-bank")
 	restPort := os.Getenv("REST_SERVER_PORT")
 	grpcPort := os.Getenv("GRPC_SERVER_PORT")
+	gatewayPort := os.Getenv("GATEWAY_SERVER_PORT")
 
 	log.Println("Initializing token maker")
-	pasetoMaker := utils.NewPasetoMaker("") // TODO: get key for token generation
+	tokenMaker, err := initTokenMaker(os.Getenv("TOKEN_TYPE"))
+	if err != nil {
+		log.Fatal("cannot create token maker: ", err)
+	}
+
+	log.Println("Initializing exchange rates")
+	exchangeRates, err := initExchangeRateProvider(os.Getenv("EXCHANGE_RATES"), os.Getenv("EXCHANGE_RATES_FILE"))
+	if err != nil {
+		log.Fatal("cannot load exchange rates: ", err)
+	}
+
+	approvalThreshold, err := parseApprovalThreshold(os.Getenv("TRANSFER_APPROVAL_THRESHOLD"))
+	if err != nil {
+		log.Fatal("cannot parse transfer approval threshold: ", err)
+	}
 
 	log.Println("Connecting to database")
 	connPool := dbserver.ConnectToDb(context.Background())
@@ -36,13 +66,163 @@ func main() {
 	store := db.NewStore(connPool)
 
 	// init service layer
-	userService := services.NewUserService(store, pasetoMaker)
+	userService := services.NewUserService(store, tokenMaker)
 	accountService := services.NewAccountService(store)
-	transferService := services.NewTransferService(store)
+	transferService := services.NewTransferService(store, exchangeRates, approvalThreshold)
+	standingOrderService := services.NewStandingOrderService(store, transferService)
+	holdService := services.NewHoldService(store, transferService)
+	ledgerService := services.NewLedgerService(store)
+
+	schedulerInterval, err := parseSchedulerInterval(os.Getenv("STANDING_ORDER_SCHEDULER_INTERVAL"), time.Minute)
+	if err != nil {
+		log.Fatal("cannot parse standing order scheduler interval: ", err)
+	}
+
+	holdExpiryInterval, err := parseSchedulerInterval(os.Getenv("HOLD_EXPIRY_INTERVAL"), time.Minute)
+	if err != nil {
+		log.Fatal("cannot parse hold expiry interval: ", err)
+	}
+
+	reconciliationInterval, err := parseSchedulerInterval(os.Getenv("RECONCILIATION_INTERVAL"), time.Hour)
+	if err != nil {
+		log.Fatal("cannot parse reconciliation interval: ", err)
+	}
+
+	balanceSnapshotInterval, err := parseSchedulerInterval(os.Getenv("BALANCE_SNAPSHOT_INTERVAL"), time.Hour)
+	if err != nil {
+		log.Fatal("cannot parse balance snapshot interval: ", err)
+	}
+
+	go worker.RunStandingOrderScheduler(context.Background(), standingOrderService, schedulerInterval)
+	go worker.RunHoldExpiry(context.Background(), holdService, holdExpiryInterval)
+	go worker.RunReconciliation(context.Background(), ledgerService, reconciliationInterval)
+	go worker.RunBalanceSnapshots(context.Background(), accountService, balanceSnapshotInterval)
+	go runRestServer(restPort, userService, accountService, transferService, standingOrderService, holdService, ledgerService, tokenMaker)
+	if gatewayPort != "" {
+		go runGatewayServer(gatewayPort, grpcPort)
+	}
+	runGrpcServer(grpcPort, userService, accountService, transferService, standingOrderService, holdService, ledgerService, tokenMaker)
+}
+
+// initTokenMaker creates the token maker for the configured token type. Local tokens are encrypted with a
+// symmetric key, public tokens are signed so that other services can verify them with the public key.
+func initTokenMaker(tokenType string) (utils.TokenMaker, error) {
+	switch tokenType {
+	case "", "local":
+		symmetricKeys, err := utils.LoadSymmetricKeys(os.Getenv("TOKEN_SYMMETRIC_KEYS"), os.Getenv("TOKEN_SYMMETRIC_KEYS_FILE"))
+		if err != nil {
+			return nil, err
+		}
+
+		tokenMaker, err := utils.NewPasetoMaker(os.Getenv("TOKEN_SYMMETRIC_KEY_ID"), symmetricKeys)
+		if err != nil {
+			return nil, err
+		}
+
+		return tokenMaker, nil
+	case "public":
+		secretKeys, err := utils.LoadAsymmetricSecretKeys(os.Getenv("TOKEN_SECRET_KEYS"), os.Getenv("TOKEN_SECRET_KEYS_FILE"))
+		if err != nil {
+			return nil, err
+		}
+
+		tokenMaker, err := utils.NewPasetoPublicMaker(os.Getenv("TOKEN_SECRET_KEY_ID"), secretKeys)
+		if err != nil {
+			return nil, err
+		}
+
+		return tokenMaker, nil
+	default:
+		return nil, fmt.Errorf("unknown token type %q", tokenType)
+	}
+}
 
-	go runRestServer(restPort, userService, accountService, transferService, pasetoMaker)
-	// go runGatewayServer(restPort, userService, accountService, transferService)
-	runGrpcServer(grpcPort, userService, accountService, transferService)
+// initExchangeRateProvider loads the static exchange rates. Without configured rates transfers between accounts
+// with different currencies are rejected.
+func initExchangeRateProvider(rateList string, rateFile string) (utils.ExchangeRateProvider, error) {
+	if rateList == "" && rateFile == "" {
+		return nil, nil
+	}
+
+	rates, err := utils.LoadExchangeRates(rateList, rateFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.NewStaticExchangeRateProvider(rates), nil
+}
+
+// parseSchedulerInterval returns how often a background worker runs, e.g. the standing order scheduler
+func parseSchedulerInterval(interval string, defaultInterval time.Duration) (time.Duration, error) {
+	if interval == "" {
+		return defaultInterval, nil
+	}
+
+	duration, err := time.ParseDuration(interval)
+	if err != nil {
+		return 0, err
+	}
+
+	if duration <= 0 {
+		return 0, fmt.Errorf("interval must be positive, got %s", interval)
+	}
+
+	return duration, nil
+}
+
+// parseApprovalThreshold returns the amount above which transfers must be approved by a banker, zero disables approvals
+func parseApprovalThreshold(threshold string) (int64, error) {
+	if threshold == "" {
+		return 0, nil
+	}
+
+	amount, err := strconv.ParseInt(threshold, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	if amount < 0 {
+		return 0, fmt.Errorf("threshold must not be negative, got %s", threshold)
+	}
+
+	return amount, nil
+}
+
+// runCommand runs a one-off command instead of the servers, e.g. `karaBank reconcile`
+func runCommand(command string, args []string) {
+	switch command {
+	case "reconcile":
+		runReconcile()
+	default:
+		log.Fatalf("unknown command %q, available commands: reconcile", command)
+	}
+}
+
+// runReconcile prints the reconciliation report as json to stdout. It exits with status 2 if the report found
+// inconsistencies, so that scripts can tell them apart from errors
+func runReconcile() {
+	connPool := dbserver.ConnectToDb(context.Background())
+	defer connPool.Close()
+
+	ledgerService := services.NewLedgerService(db.NewStore(connPool))
+
+	report, err := ledgerService.Reconcile(context.Background(), time.Now())
+	if err != nil {
+		log.Fatal("cannot reconcile ledger: ", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	err = encoder.Encode(report)
+	if err != nil {
+		log.Fatal("cannot write reconciliation report: ", err)
+	}
+
+	if !report.Consistent {
+		connPool.Close()
+		os.Exit(2)
+	}
 }
 
 func runGrpcServer(
@@ -50,11 +230,19 @@ func runGrpcServer(
 	userService services.UserServiceInterface,
 	accountService services.AccountServiceInterface,
 	transferService services.TransferServiceInterface,
+	standingOrderService services.StandingOrderServiceInterface,
+	holdService services.HoldServiceInterface,
+	ledgerService services.LedgerServiceInterface,
+	tokenMaker utils.TokenMaker,
 ) {
 	log.Println("Initializing grpc server")
-	handler := gapi.InitGrpcHandler(userService, accountService, transferService)
+	handler := gapi.InitGrpcHandler(userService, accountService, transferService, standingOrderService, holdService, ledgerService)
+	authInterceptor := gapi.NewAuthInterceptor(tokenMaker, userService)
 
-	server := grpc.NewServer()
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(authInterceptor.Unary()),
+		grpc.StreamInterceptor(authInterceptor.Stream()),
+	)
 	pb.RegisterKaraBankServer(server, handler)
 	reflection.Register(server)
 	address := "0.0.0.0" + grpcPort
@@ -71,43 +259,60 @@ func runGrpcServer(
 
 }
 
-func runGatewayServer(
-	port string,
-	userService services.UserServiceInterface,
-	accountService services.AccountServiceInterface,
-	transferService services.TransferServiceInterface,
-) {
+// gatewayHeaderMatcher forwards the idempotency key of http requests to the grpc server in addition to the
+// headers that the gateway forwards by default
+func gatewayHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, "Idempotency-Key") {
+		return "idempotency-key", true
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// runGatewayServer serves the http api defined in the proto files. The gateway forwards every request to the
+// grpc server instead of calling the handlers directly, so that the same interceptors apply to both transports.
+func runGatewayServer(port string, grpcPort string) {
 	log.Println("Initializing grpc gateway")
-	handler := gapi.InitGrpcHandler(userService, accountService, transferService)
 
 	jsonOption := runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
 		MarshalOptions: protojson.MarshalOptions{
 			UseProtoNames: true,
+			// list responses always contain has_more and next_cursor like the rest api
+			EmitUnpopulated: true,
 		},
 		UnmarshalOptions: protojson.UnmarshalOptions{
 			DiscardUnknown: true,
 		},
 	})
 
-	grpcMux := runtime.NewServeMux(jsonOption)
+	grpcMux := runtime.NewServeMux(jsonOption, runtime.WithIncomingHeaderMatcher(gatewayHeaderMatcher))
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	err := pb.RegisterKaraBankHandlerServer(ctx, grpcMux, handler)
+	dialOptions := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}
+
+	err := pb.RegisterKaraBankHandlerFromEndpoint(ctx, grpcMux, "localhost"+grpcPort, dialOptions)
 	if err != nil {
-		log.Fatal("cannot register handler server: ", err)
+		log.Fatal("cannot register handler from endpoint: ", err)
 	}
 
 	mux := http.NewServeMux()
 	mux.Handle("/", grpcMux)
 
-	address := "0.0.0.0" + port
-	listener, err := net.Listen("tcp", address)
-	if err != nil {
-		log.Fatal("cannot create listener: ", err)
+	gatewayServer := &http.Server{
+		Addr:              "0.0.0.0" + port,
+		Handler:           http.MaxBytesHandler(mux, gatewayMaxBodyBytes),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       15 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       2 * time.Minute,
+		MaxHeaderBytes:    1 << 16,
 	}
 
-	err = http.Serve(listener, mux)
+	log.Printf("Starting gateway on port %s", port)
+	err = gatewayServer.ListenAndServe()
 	if err != nil {
 		log.Fatal("cannot start gateway server: ", err)
 	}
@@ -118,10 +323,13 @@ func runRestServer(
 	userService services.UserServiceInterface,
 	accountService services.AccountServiceInterface,
 	transferService services.TransferServiceInterface,
+	standingOrderService services.StandingOrderServiceInterface,
+	holdService services.HoldServiceInterface,
+	ledgerService services.LedgerServiceInterface,
 	tokenMaker utils.TokenMaker,
 ) {
 	log.Println("Initializing rest server")
-	httpServer := server.InitHttpServer(port, userService, accountService, transferService, tokenMaker)
+	httpServer := server.InitHttpServer(port, userService, accountService, transferService, standingOrderService, holdService, ledgerService, tokenMaker)
 
 	log.Printf("Starting app on port %s", port)
 	err := httpServer.ListenAndServe()
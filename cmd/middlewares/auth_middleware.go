@@ -2,60 +2,90 @@ package middlewares
 
 import (
 	"context"
+	"errors"
+	"kara-bank/services"
 	"kara-bank/utils"
 	"net/http"
+	"strings"
 )
 
 type contextUserEmail string
 type contextUserRole string
+type contextSessionId string
 
 const ContextUserEmailKey contextUserEmail = "userEmail"
 const ContextUserRoleKey contextUserRole = "userRole"
+const ContextSessionIdKey contextSessionId = "sessionId"
 
-func AuthMiddleware(tokenMaker utils.TokenMaker, next http.Handler) http.Handler {
+func AuthMiddleware(tokenMaker utils.TokenMaker, userService services.UserServiceInterface, routes *utils.RouteRegistry) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		requestTarget := r.Method + " " + r.URL.Path
+		policy, ok := routes.Policy(r)
 
-		endpointRoles, err := utils.IsProtectedRoute(requestTarget)
-
-		if err != nil {
-			http.Error(w, "Could not find roles for this endpoint", http.StatusInternalServerError)
+		// unknown routes and methods are answered by the router with 404 or 405
+		if !ok || policy.Public {
+			routes.ServeHTTP(w, r)
 			return
 		}
 
-		if endpointRoles == nil {
-			http.Error(w, "Could not find roles for this endpoint", http.StatusInternalServerError)
-			return
-		}
+		authToken, err := getAccessToken(r)
 
-		if endpointRoles[0] == "" {
-			next.ServeHTTP(w, r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
 			return
 		}
 
-		authToken, err := r.Cookie("access_token")
+		verifiedToken, err := tokenMaker.VerifyToken(authToken)
 
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusUnauthorized)
 			return
 		}
 
-		verifiedToken, err := tokenMaker.VerifyToken(authToken.Value)
+		// refresh tokens live much longer and must only be sent to the refresh endpoint
+		if verifiedToken.Type != utils.AccessTokenType {
+			http.Error(w, utils.ErrWrongTokenType.Error(), http.StatusUnauthorized)
+			return
+		}
 
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusUnauthorized)
+		respErr := userService.ValidateSession(r.Context(), verifiedToken.SessionID, verifiedToken.Email)
+
+		if respErr != nil {
+			http.Error(w, respErr.Message, respErr.Status)
 			return
 		}
 
-		for _, v := range endpointRoles {
-			if verifiedToken.Role == v {
-				ctx := context.WithValue(r.Context(), ContextUserEmailKey, verifiedToken.Email)
-				ctx = context.WithValue(ctx, ContextUserRoleKey, verifiedToken.Role)
-				next.ServeHTTP(w, r.WithContext(ctx))
-				return
-			}
+		if !policy.Allows(verifiedToken.Role) {
+			http.Error(w, "You do not have the right role to do this", http.StatusUnauthorized)
+			return
 		}
 
-		http.Error(w, "You do not have the right role to do this", http.StatusUnauthorized)
+		ctx := context.WithValue(r.Context(), ContextUserEmailKey, verifiedToken.Email)
+		ctx = context.WithValue(ctx, ContextUserRoleKey, verifiedToken.Role)
+		ctx = context.WithValue(ctx, ContextSessionIdKey, verifiedToken.SessionID)
+		routes.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// getAccessToken reads the access token from the Authorization header or, if the header is not set, from the access_token cookie.
+// A malformed Authorization header is an error and does not fall back to the cookie.
+func getAccessToken(r *http.Request) (string, error) {
+	authorizationHeader := r.Header.Get("Authorization")
+
+	if authorizationHeader != "" {
+		fields := strings.Fields(authorizationHeader)
+
+		if len(fields) != 2 || !strings.EqualFold(fields[0], "bearer") {
+			return "", errors.New("invalid authorization header format")
+		}
+
+		return fields[1], nil
+	}
+
+	authToken, err := r.Cookie("access_token")
+
+	if err != nil {
+		return "", err
+	}
+
+	return authToken.Value, nil
+}
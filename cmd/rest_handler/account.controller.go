@@ -40,6 +40,7 @@ func (a *AccountController) HandleCreateAccount(w http.ResponseWriter, r *http.R
 	}
 
 	requestBody.Owner = email
+	requestBody.IdempotencyKey = r.Header.Get(idempotencyKeyHeader)
 	err = a.validator.Struct(requestBody)
 
 	if err != nil {
@@ -109,15 +110,19 @@ func (a *AccountController) HandleGetAccount(w http.ResponseWriter, r *http.Requ
 }
 
 func (a *AccountController) HandleListAccounts(w http.ResponseWriter, r *http.Request) {
-	var requestBody dto.ListAccountsDto
-	err := json.NewDecoder(r.Body).Decode(&requestBody)
+	query, err := parsePageQuery(r.URL.Query())
 
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	err = a.validator.Struct(requestBody)
+	args := dto.ListAccountsDto{
+		Limit:  query.limit,
+		Cursor: query.cursor,
+	}
+
+	err = a.validator.Struct(args)
 
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
@@ -131,7 +136,7 @@ func (a *AccountController) HandleListAccounts(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	accounts, respErr := a.accountService.ListAccounts(r.Context(), &requestBody, role)
+	accounts, respErr := a.accountService.ListAccounts(r.Context(), &args, role)
 
 	if respErr != nil {
 		http.Error(w, respErr.Message, respErr.Status)
@@ -149,3 +154,232 @@ func (a *AccountController) HandleListAccounts(w http.ResponseWriter, r *http.Re
 	w.WriteHeader(http.StatusOK)
 	w.Write(responseJson)
 }
+
+func (a *AccountController) HandleListOwnAccounts(w http.ResponseWriter, r *http.Request) {
+	query, err := parsePageQuery(r.URL.Query())
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	args := dto.ListAccountsDto{
+		Limit:  query.limit,
+		Cursor: query.cursor,
+	}
+
+	err = a.validator.Struct(args)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	email, ok := r.Context().Value(middlewares.ContextUserEmailKey).(string)
+
+	if !ok {
+		http.Error(w, "Could not convert email from token to string", http.StatusInternalServerError)
+		return
+	}
+
+	accounts, respErr := a.accountService.ListOwnAccounts(r.Context(), &args, email)
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	responseJson, err := json.Marshal(&accounts)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseJson)
+}
+
+func (a *AccountController) HandleSetOverdraftLimit(w http.ResponseWriter, r *http.Request) {
+	pathValue := r.PathValue("id")
+	id, err := strconv.Atoi(pathValue)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var requestBody dto.SetOverdraftLimitDto
+	err = json.NewDecoder(r.Body).Decode(&requestBody)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	requestBody.AccountId = int64(id)
+	err = a.validator.Struct(requestBody)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	role, ok := r.Context().Value(middlewares.ContextUserRoleKey).(string)
+
+	if !ok {
+		http.Error(w, "Could not convert role from token to string", http.StatusInternalServerError)
+		return
+	}
+
+	account, respErr := a.accountService.SetOverdraftLimit(r.Context(), &requestBody, role)
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	responseJson, err := json.Marshal(&account)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseJson)
+}
+
+func (a *AccountController) HandleListEntries(w http.ResponseWriter, r *http.Request) {
+	pathValue := r.PathValue("id")
+	id, err := strconv.Atoi(pathValue)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	query, err := parseHistoryQuery(r.URL.Query())
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	args := dto.ListEntriesDto{
+		AccountId: int64(id),
+		Limit:     query.limit,
+		Cursor:    query.cursor,
+		StartTime: query.startTime,
+		EndTime:   query.endTime,
+		Direction: query.direction,
+	}
+
+	err = a.validator.Struct(args)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	email, ok := r.Context().Value(middlewares.ContextUserEmailKey).(string)
+
+	if !ok {
+		http.Error(w, "Could not convert email from token to string", http.StatusInternalServerError)
+		return
+	}
+
+	role, ok := r.Context().Value(middlewares.ContextUserRoleKey).(string)
+
+	if !ok {
+		http.Error(w, "Could not convert role from token to string", http.StatusInternalServerError)
+		return
+	}
+
+	entries, respErr := a.accountService.ListEntries(r.Context(), &args, email, role)
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	responseJson, err := json.Marshal(&entries)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseJson)
+}
+
+// HandleGetAccountBalance returns the balance of the account at the time given by the required query parameter at
+func (a *AccountController) HandleGetAccountBalance(w http.ResponseWriter, r *http.Request) {
+	pathValue := r.PathValue("id")
+	id, err := strconv.Atoi(pathValue)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	at, err := parseTimeQuery(r.URL.Query(), "at")
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if at == nil {
+		http.Error(w, "at is required", http.StatusBadRequest)
+		return
+	}
+
+	args := dto.GetAccountBalanceDto{
+		AccountId: int64(id),
+		At:        *at,
+	}
+
+	err = a.validator.Struct(args)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	email, ok := r.Context().Value(middlewares.ContextUserEmailKey).(string)
+
+	if !ok {
+		http.Error(w, "Could not convert email from token to string", http.StatusInternalServerError)
+		return
+	}
+
+	role, ok := r.Context().Value(middlewares.ContextUserRoleKey).(string)
+
+	if !ok {
+		http.Error(w, "Could not convert role from token to string", http.StatusInternalServerError)
+		return
+	}
+
+	balance, respErr := a.accountService.GetAccountBalance(r.Context(), &args, email, role)
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	responseJson, err := json.Marshal(&balance)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseJson)
+}
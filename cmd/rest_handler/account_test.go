@@ -33,7 +33,7 @@ func TestAccountControllerTestSuite(t *testing.T) {
 
 func (suite *AccountControllerTestSuite) SetupSuite() {
 	suite.ctx = context.Background()
-	tokenMaker := utils.NewPasetoMaker("")
+	tokenMaker := newTestTokenMaker()
 
 	userService := services.NewUserService(testStore, tokenMaker)
 	userController := NewUserController(userService, validator.New(validator.WithRequiredStructEnabled()))
@@ -41,18 +41,18 @@ func (suite *AccountControllerTestSuite) SetupSuite() {
 	accountService := services.NewAccountService(testStore)
 	accountController := NewAccountController(accountService, validator.New(validator.WithRequiredStructEnabled()))
 
-	router := http.NewServeMux()
+	router := utils.NewRouteRegistry()
 
-	router.HandleFunc("POST /users/register", userController.HandleRegisterUser)
-	router.HandleFunc("POST /users/login", userController.HandleLoginUser)
+	router.HandleFunc("POST /users/register", utils.PublicRoute(), userController.HandleRegisterUser)
+	router.HandleFunc("POST /users/login", utils.PublicRoute(), userController.HandleLoginUser)
 
-	router.HandleFunc("POST /accounts", accountController.HandleCreateAccount)
-	router.HandleFunc("GET /accounts/{id}", accountController.HandleGetAccount)
-	router.HandleFunc("GET /accounts", accountController.HandleListAccounts)
+	router.HandleFunc("POST /accounts", utils.AllowRoles(utils.CustomerRole), accountController.HandleCreateAccount)
+	router.HandleFunc("GET /accounts/{id}", utils.AllowRoles(utils.AllRoles...), accountController.HandleGetAccount)
+	router.HandleFunc("GET /accounts", utils.AllowRoles(utils.BankerRole, utils.AdminRole), accountController.HandleListAccounts)
+	router.HandleFunc("GET /users/me/accounts", utils.AllowRoles(utils.AllRoles...), accountController.HandleListOwnAccounts)
+	router.HandleFunc("PUT /accounts/{id}/overdraft-limit", utils.AllowRoles(utils.BankerRole, utils.AdminRole), accountController.HandleSetOverdraftLimit)
 
-	routerWithMiddleware := middlewares.AuthMiddleware(tokenMaker, router)
-
-	utils.SetProtectedRoutes()
+	routerWithMiddleware := middlewares.AuthMiddleware(tokenMaker, userService, router)
 
 	suite.router = routerWithMiddleware
 }
@@ -65,6 +65,9 @@ func (suite *AccountControllerTestSuite) AfterTest(suiteName string, testName st
 	_, err = testStore.ClearSessionsTable()
 	require.NoError(suite.T(), err)
 
+	_, err = testStore.ClearIdempotencyKeysTable()
+	require.NoError(suite.T(), err)
+
 	_, err = testStore.ClearUsersTable()
 	require.NoError(suite.T(), err)
 }
@@ -117,6 +120,83 @@ func (suite *AccountControllerTestSuite) TestCreateAccountSuccess() {
 	require.Equal(suite.T(), http.StatusCreated, recorder.Result().StatusCode)
 }
 
+func (suite *AccountControllerTestSuite) TestCreateAccountWithBearerToken() {
+	registerUserParam := &dto.RegisterUserDto{
+		Email:     "Max@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Max",
+		LastName:  "Mustermann",
+	}
+	accessTokenCookie := registerUserAndLogin(registerUserParam, suite.router, suite.T())
+	require.NotNil(suite.T(), accessTokenCookie)
+
+	// login again and ask for the tokens in the response body
+	loginRequestParam := &dto.LoginUserDto{
+		Email:        registerUserParam.Email,
+		Password:     registerUserParam.Password,
+		ReturnTokens: true,
+	}
+	var loginBody bytes.Buffer
+	err := json.NewEncoder(&loginBody).Encode(loginRequestParam)
+	require.NoError(suite.T(), err)
+
+	request := httptest.NewRequest("POST", "/users/login", &loginBody)
+	request.RemoteAddr = "test"
+	request.Header.Set("User-Agent", "test")
+	recorder := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusOK, recorder.Result().StatusCode)
+
+	var loginResult dto.LoginUserResultDto
+	err = json.NewDecoder(recorder.Result().Body).Decode(&loginResult)
+	require.NoError(suite.T(), err)
+	require.NotEmpty(suite.T(), loginResult.AccessToken)
+	require.NotEmpty(suite.T(), loginResult.RefreshToken)
+
+	createAccountParam := &dto.CreateAccountDto{
+		Currency: "EUR",
+	}
+	var body bytes.Buffer
+	err = json.NewEncoder(&body).Encode(createAccountParam)
+	require.NoError(suite.T(), err)
+
+	request = httptest.NewRequest("POST", "/accounts", &body)
+	request.Header.Set("Authorization", "Bearer "+loginResult.AccessToken)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+
+	require.Equal(suite.T(), http.StatusCreated, recorder.Result().StatusCode)
+}
+
+func (suite *AccountControllerTestSuite) TestAuthorizationHeaderTakesPrecedenceOverCookie() {
+	accessTokenCookie := registerUserAndLogin(&dto.RegisterUserDto{
+		Email:     "Max@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Max",
+		LastName:  "Mustermann",
+	}, suite.router, suite.T())
+	require.NotNil(suite.T(), accessTokenCookie)
+
+	createAccountParam := &dto.CreateAccountDto{
+		Currency: "EUR",
+	}
+	var body bytes.Buffer
+	err := json.NewEncoder(&body).Encode(createAccountParam)
+	require.NoError(suite.T(), err)
+
+	// a valid cookie does not rescue an invalid authorization header
+	request := httptest.NewRequest("POST", "/accounts", &body)
+	request.AddCookie(accessTokenCookie)
+	request.Header.Set("Authorization", "Bearer invalid")
+	recorder := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+
+	require.Equal(suite.T(), http.StatusUnauthorized, recorder.Result().StatusCode)
+}
+
 func (suite *AccountControllerTestSuite) TestGetOneAccountNotFound() {
 	accessTokenCookie := registerUserAndLogin(&dto.RegisterUserDto{
 		Email:     "Max@Mustermann.de",
@@ -200,15 +280,7 @@ func (suite *AccountControllerTestSuite) TestListAccountsFailWrongRole() {
 	}
 	accessToken := loginUser(loginUserDto, suite.router, suite.T())
 
-	listAccountsDto := &dto.ListAccountsDto{
-		Offset: 0,
-		Limit:  5,
-	}
-	var body bytes.Buffer
-	err = json.NewEncoder(&body).Encode(listAccountsDto)
-	require.NoError(suite.T(), err)
-
-	request := httptest.NewRequest("GET", "/accounts", &body)
+	request := httptest.NewRequest("GET", "/accounts?limit=5", nil)
 	request.AddCookie(accessToken)
 	recorder := httptest.NewRecorder()
 
@@ -237,20 +309,188 @@ func (suite *AccountControllerTestSuite) TestListAccountsSuccess() {
 	}
 	accessToken := loginUser(loginUserDto, suite.router, suite.T())
 
-	listAccountsDto := &dto.ListAccountsDto{
-		Offset: 0,
-		Limit:  5,
+	// three accounts of two customers
+	customerParam1 := &dto.RegisterUserDto{
+		Email:     "Tom@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Tom",
+		LastName:  "Mustermann",
 	}
-	var body bytes.Buffer
-	err = json.NewEncoder(&body).Encode(listAccountsDto)
+
+	customerAccessToken1 := registerUserAndLogin(customerParam1, suite.router, suite.T())
+	createAccount(customerAccessToken1, "EUR", suite.router, suite.T())
+	createAccount(customerAccessToken1, "USD", suite.router, suite.T())
+
+	customerParam2 := &dto.RegisterUserDto{
+		Email:     "Tim@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Tim",
+		LastName:  "Mustermann",
+	}
+
+	customerAccessToken2 := registerUserAndLogin(customerParam2, suite.router, suite.T())
+	createAccount(customerAccessToken2, "EUR", suite.router, suite.T())
+
+	// first page
+	request := httptest.NewRequest("GET", "/accounts?limit=2", nil)
+	request.AddCookie(accessToken)
+	recorder := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusOK, recorder.Result().StatusCode)
+
+	var firstPage dto.PageDto[db.Account]
+	err = json.NewDecoder(recorder.Result().Body).Decode(&firstPage)
 	require.NoError(suite.T(), err)
+	require.Len(suite.T(), firstPage.Items, 2)
+	require.True(suite.T(), firstPage.HasMore)
+	require.NotEmpty(suite.T(), firstPage.NextCursor)
 
-	request := httptest.NewRequest("GET", "/accounts", &body)
+	// second page continues behind the last account of the first page
+	request = httptest.NewRequest("GET", "/accounts?limit=2&cursor="+firstPage.NextCursor, nil)
 	request.AddCookie(accessToken)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusOK, recorder.Result().StatusCode)
+
+	var secondPage dto.PageDto[db.Account]
+	err = json.NewDecoder(recorder.Result().Body).Decode(&secondPage)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), secondPage.Items, 1)
+	require.False(suite.T(), secondPage.HasMore)
+	require.Empty(suite.T(), secondPage.NextCursor)
+	require.Greater(suite.T(), secondPage.Items[0].ID, firstPage.Items[1].ID)
+
+	request = httptest.NewRequest("GET", "/accounts?limit=2&cursor=invalid", nil)
+	request.AddCookie(accessToken)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusBadRequest, recorder.Result().StatusCode)
+}
+
+func (suite *AccountControllerTestSuite) TestListOwnAccounts() {
+	registerUserParam1 := &dto.RegisterUserDto{
+		Email:     "Max@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Max",
+		LastName:  "Mustermann",
+	}
+
+	accessToken1 := registerUserAndLogin(registerUserParam1, suite.router, suite.T())
+	euroAccount := createAccount(accessToken1, "EUR", suite.router, suite.T())
+	dollarAccount := createAccount(accessToken1, "USD", suite.router, suite.T())
+
+	_, err := testStore.SetAccountBalance(suite.ctx, euroAccount.ID, 100)
+	require.NoError(suite.T(), err)
+
+	registerUserParam2 := &dto.RegisterUserDto{
+		Email:     "Tom@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Tom",
+		LastName:  "Mustermann",
+	}
+
+	accessToken2 := registerUserAndLogin(registerUserParam2, suite.router, suite.T())
+	createAccount(accessToken2, "EUR", suite.router, suite.T())
+
+	// only the accounts of the logged in user are listed
+	request := httptest.NewRequest("GET", "/users/me/accounts?limit=10", nil)
+	request.AddCookie(accessToken1)
 	recorder := httptest.NewRecorder()
 
 	suite.router.ServeHTTP(recorder, request)
 	require.Equal(suite.T(), http.StatusOK, recorder.Result().StatusCode)
+
+	var page dto.PageDto[db.Account]
+	err = json.NewDecoder(recorder.Result().Body).Decode(&page)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), page.Items, 2)
+	require.False(suite.T(), page.HasMore)
+
+	require.Equal(suite.T(), euroAccount.ID, page.Items[0].ID)
+	require.Equal(suite.T(), "EUR", page.Items[0].Currency)
+	require.Equal(suite.T(), int64(100), page.Items[0].Balance)
+	require.Equal(suite.T(), dollarAccount.ID, page.Items[1].ID)
+	require.Equal(suite.T(), "USD", page.Items[1].Currency)
+
+	for _, account := range page.Items {
+		require.Equal(suite.T(), registerUserParam1.Email, account.Owner)
+	}
+}
+
+func (suite *AccountControllerTestSuite) TestSetOverdraftLimit() {
+	customerParam := &dto.RegisterUserDto{
+		Email:     "Max@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Max",
+		LastName:  "Mustermann",
+	}
+	customerAccessToken := registerUserAndLogin(customerParam, suite.router, suite.T())
+	account := createAccount(customerAccessToken, "EUR", suite.router, suite.T())
+
+	hashedPasswordBytes, err := bcrypt.GenerateFromPassword([]byte("Test1234"), bcrypt.DefaultCost)
+	require.NoError(suite.T(), err)
+
+	banker, err := testStore.RegisterUser(suite.ctx, &db.RegisterUserParams{
+		Email:          "Tom@Mustermann.de",
+		HashedPassword: string(hashedPasswordBytes),
+		FirstName:      "Tom",
+		LastName:       "Mustermann",
+		UserRole:       utils.BankerRole,
+	})
+	require.NoError(suite.T(), err)
+
+	bankerAccessToken := loginUser(&dto.LoginUserDto{
+		Email:    banker.Email,
+		Password: "Test1234",
+	}, suite.router, suite.T())
+
+	path := "/accounts/" + strconv.FormatInt(account.ID, 10) + "/overdraft-limit"
+
+	// customers cannot change their own limit
+	request := httptest.NewRequest("PUT", path, bytes.NewReader([]byte(`{"overdraft_limit": 500}`)))
+	request.AddCookie(customerAccessToken)
+	recorder := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusUnauthorized, recorder.Result().StatusCode)
+
+	request = httptest.NewRequest("PUT", path, bytes.NewReader([]byte(`{"overdraft_limit": -1}`)))
+	request.AddCookie(bankerAccessToken)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusBadRequest, recorder.Result().StatusCode)
+
+	request = httptest.NewRequest("PUT", path, bytes.NewReader([]byte(`{"overdraft_limit": 500}`)))
+	request.AddCookie(bankerAccessToken)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusOK, recorder.Result().StatusCode)
+
+	var updatedAccount db.Account
+	err = json.NewDecoder(recorder.Result().Body).Decode(&updatedAccount)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), int64(500), updatedAccount.OverdraftLimit)
+}
+
+func (suite *AccountControllerTestSuite) TestUnknownRouteNotFound() {
+	request := httptest.NewRequest("GET", "/unknown", nil)
+	recorder := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusNotFound, recorder.Result().StatusCode)
+}
+
+func (suite *AccountControllerTestSuite) TestWrongMethodNotAllowed() {
+	request := httptest.NewRequest("DELETE", "/accounts", nil)
+	recorder := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusMethodNotAllowed, recorder.Result().StatusCode)
 }
 
 // helper function for test suits that need accounts
@@ -0,0 +1,244 @@
+package rest
+
+import (
+	"encoding/json"
+	"kara-bank/dto"
+	"kara-bank/middlewares"
+	"kara-bank/services"
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type HoldController struct {
+	holdService services.HoldServiceInterface
+	validator   *validator.Validate
+}
+
+func NewHoldController(holdService services.HoldServiceInterface, validator *validator.Validate) *HoldController {
+	return &HoldController{
+		holdService: holdService,
+		validator:   validator,
+	}
+}
+
+func (h *HoldController) HandleCreateHold(w http.ResponseWriter, r *http.Request) {
+	pathValue := r.PathValue("id")
+	id, err := strconv.Atoi(pathValue)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var requestBody dto.CreateHoldDto
+	err = json.NewDecoder(r.Body).Decode(&requestBody)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	requestBody.AccountId = int64(id)
+	err = h.validator.Struct(requestBody)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	hold, respErr := h.holdService.CreateHold(r.Context(), &requestBody)
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	responseJson, err := json.Marshal(&hold)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(responseJson)
+}
+
+func (h *HoldController) HandleGetHold(w http.ResponseWriter, r *http.Request) {
+	pathValue := r.PathValue("id")
+	id, err := strconv.Atoi(pathValue)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	email, ok := r.Context().Value(middlewares.ContextUserEmailKey).(string)
+
+	if !ok {
+		http.Error(w, "Could not convert email from token to string", http.StatusInternalServerError)
+		return
+	}
+
+	role, ok := r.Context().Value(middlewares.ContextUserRoleKey).(string)
+
+	if !ok {
+		http.Error(w, "Could not convert role from token to string", http.StatusInternalServerError)
+		return
+	}
+
+	hold, respErr := h.holdService.GetHold(r.Context(), int64(id), email, role)
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	responseJson, err := json.Marshal(&hold)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseJson)
+}
+
+func (h *HoldController) HandleListHolds(w http.ResponseWriter, r *http.Request) {
+	pathValue := r.PathValue("id")
+	id, err := strconv.Atoi(pathValue)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	query, err := parsePageQuery(r.URL.Query())
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	args := dto.ListHoldsDto{
+		AccountId: int64(id),
+		Limit:     query.limit,
+		Cursor:    query.cursor,
+	}
+
+	err = h.validator.Struct(args)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	email, ok := r.Context().Value(middlewares.ContextUserEmailKey).(string)
+
+	if !ok {
+		http.Error(w, "Could not convert email from token to string", http.StatusInternalServerError)
+		return
+	}
+
+	role, ok := r.Context().Value(middlewares.ContextUserRoleKey).(string)
+
+	if !ok {
+		http.Error(w, "Could not convert role from token to string", http.StatusInternalServerError)
+		return
+	}
+
+	holds, respErr := h.holdService.ListHolds(r.Context(), &args, email, role)
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	responseJson, err := json.Marshal(&holds)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseJson)
+}
+
+func (h *HoldController) HandleCaptureHold(w http.ResponseWriter, r *http.Request) {
+	pathValue := r.PathValue("id")
+	id, err := strconv.Atoi(pathValue)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var requestBody dto.CaptureHoldDto
+	err = json.NewDecoder(r.Body).Decode(&requestBody)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	requestBody.HoldId = int64(id)
+	err = h.validator.Struct(requestBody)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, respErr := h.holdService.CaptureHold(r.Context(), &requestBody)
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	responseJson, err := json.Marshal(&result)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseJson)
+}
+
+func (h *HoldController) HandleReleaseHold(w http.ResponseWriter, r *http.Request) {
+	pathValue := r.PathValue("id")
+	id, err := strconv.Atoi(pathValue)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	hold, respErr := h.holdService.ReleaseHold(r.Context(), int64(id))
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	responseJson, err := json.Marshal(&hold)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseJson)
+}
@@ -0,0 +1,281 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	db "kara-bank/db/repositories"
+	"kara-bank/dto"
+	"kara-bank/middlewares"
+	"kara-bank/services"
+	"kara-bank/utils"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type HoldControllerTestSuite struct {
+	suite.Suite
+	ctx         context.Context
+	router      http.Handler
+	holdService *services.HoldServiceImpl
+}
+
+func TestHoldSuite(t *testing.T) {
+	suite.Run(t, &HoldControllerTestSuite{})
+}
+
+func (suite *HoldControllerTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+	tokenMaker := newTestTokenMaker()
+	validatorObj := validator.New(validator.WithRequiredStructEnabled())
+
+	userService := services.NewUserService(testStore, tokenMaker)
+	userController := NewUserController(userService, validatorObj)
+
+	accountService := services.NewAccountService(testStore)
+	accountController := NewAccountController(accountService, validatorObj)
+
+	transferService := services.NewTransferService(testStore, utils.NewStaticExchangeRateProvider(map[string]utils.ExchangeRate{}), 0)
+
+	suite.holdService = services.NewHoldService(testStore, transferService)
+	holdController := NewHoldController(suite.holdService, validatorObj)
+
+	router := utils.NewRouteRegistry()
+
+	router.HandleFunc("POST /users/register", utils.PublicRoute(), userController.HandleRegisterUser)
+	router.HandleFunc("POST /users/login", utils.PublicRoute(), userController.HandleLoginUser)
+
+	router.HandleFunc("POST /accounts", utils.AllowRoles(utils.CustomerRole), accountController.HandleCreateAccount)
+	router.HandleFunc("POST /accounts/{id}/holds", utils.AllowRoles(utils.BankerRole, utils.AdminRole), holdController.HandleCreateHold)
+	router.HandleFunc("GET /accounts/{id}/holds", utils.AllowRoles(utils.AllRoles...), holdController.HandleListHolds)
+
+	router.HandleFunc("GET /holds/{id}", utils.AllowRoles(utils.AllRoles...), holdController.HandleGetHold)
+	router.HandleFunc("POST /holds/{id}/capture", utils.AllowRoles(utils.BankerRole, utils.AdminRole), holdController.HandleCaptureHold)
+	router.HandleFunc("POST /holds/{id}/release", utils.AllowRoles(utils.BankerRole, utils.AdminRole), holdController.HandleReleaseHold)
+
+	routerWithMiddleware := middlewares.AuthMiddleware(tokenMaker, userService, router)
+
+	suite.router = routerWithMiddleware
+}
+
+func (suite *HoldControllerTestSuite) AfterTest(suiteName string, testName string) {
+	// clear tables after every test to avoid dependencies and side effects between tests
+	_, err := testStore.ClearHoldsTable()
+	require.NoError(suite.T(), err)
+
+	_, err = testStore.ClearEntriesTable()
+	require.NoError(suite.T(), err)
+
+	_, err = testStore.ClearPostingsTable()
+	require.NoError(suite.T(), err)
+
+	_, err = testStore.ClearJournalsTable()
+	require.NoError(suite.T(), err)
+
+	_, err = testStore.ClearTransfersTable()
+	require.NoError(suite.T(), err)
+
+	_, err = testStore.ClearAccountsTable()
+	require.NoError(suite.T(), err)
+
+	_, err = testStore.ClearSessionsTable()
+	require.NoError(suite.T(), err)
+
+	_, err = testStore.ClearUsersTable()
+	require.NoError(suite.T(), err)
+}
+
+func (suite *HoldControllerTestSuite) TestCaptureHold() {
+	registerUserParam1 := &dto.RegisterUserDto{
+		Email:     "Max@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Max",
+		LastName:  "Mustermann",
+	}
+
+	accessToken1 := registerUserAndLogin(registerUserParam1, suite.router, suite.T())
+	account1 := createAccount(accessToken1, "EUR", suite.router, suite.T())
+
+	_, err := testStore.SetAccountBalance(suite.ctx, account1.ID, 1000)
+	require.NoError(suite.T(), err)
+
+	registerUserParam2 := &dto.RegisterUserDto{
+		Email:     "Tom@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Tom",
+		LastName:  "Mustermann",
+	}
+
+	accessToken2 := registerUserAndLogin(registerUserParam2, suite.router, suite.T())
+	account2 := createAccount(accessToken2, "EUR", suite.router, suite.T())
+
+	bankerAccessToken := registerBankerAndLogin("Tim@Mustermann.de", suite.router, suite.T())
+
+	// customers cannot reserve money
+	recorder := suite.createHold(accessToken1, account1.ID, &dto.CreateHoldDto{Amount: 300})
+	require.Equal(suite.T(), http.StatusUnauthorized, recorder.Result().StatusCode)
+
+	recorder = suite.createHold(bankerAccessToken, account1.ID, &dto.CreateHoldDto{
+		Amount:      300,
+		Description: "card payment",
+	})
+	require.Equal(suite.T(), http.StatusCreated, recorder.Result().StatusCode)
+
+	var hold db.Hold
+	err = json.NewDecoder(recorder.Result().Body).Decode(&hold)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), db.HoldActive, hold.Status)
+	require.Equal(suite.T(), "card payment", hold.Description)
+	require.WithinDuration(suite.T(), time.Now().Add(7*24*time.Hour), *hold.ExpiresAt, time.Minute)
+
+	account, err := testStore.GetAccount(suite.ctx, account1.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), int64(1000), account.Balance)
+	require.Equal(suite.T(), int64(700), account.AvailableBalance)
+
+	// held money cannot be held twice
+	recorder = suite.createHold(bankerAccessToken, account1.ID, &dto.CreateHoldDto{Amount: 701})
+	require.Equal(suite.T(), http.StatusUnprocessableEntity, recorder.Result().StatusCode)
+
+	// the owner of the account can see its holds
+	for accessToken, expectedCode := range map[*http.Cookie]int{accessToken1: http.StatusOK, accessToken2: http.StatusUnauthorized} {
+		request := httptest.NewRequest("GET", fmt.Sprintf("/accounts/%d/holds?limit=10", account1.ID), nil)
+		request.AddCookie(accessToken)
+		recorder = httptest.NewRecorder()
+
+		suite.router.ServeHTTP(recorder, request)
+		require.Equal(suite.T(), expectedCode, recorder.Result().StatusCode)
+	}
+
+	// the captured amount is transferred and the rest of the hold is released
+	captureParam := &dto.CaptureHoldDto{
+		ToAccountId: account2.ID,
+		Amount:      200,
+	}
+
+	recorder = suite.captureHold(bankerAccessToken, hold.ID, captureParam)
+	require.Equal(suite.T(), http.StatusOK, recorder.Result().StatusCode)
+
+	var captured db.CaptureHoldTxResult
+	err = json.NewDecoder(recorder.Result().Body).Decode(&captured)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), db.HoldCaptured, captured.Hold.Status)
+	require.Equal(suite.T(), captured.Transfer.ID, *captured.Hold.TransferID)
+	require.Equal(suite.T(), int64(200), captured.Transfer.Amount)
+	require.Equal(suite.T(), int64(800), captured.FromAccount.Balance)
+	require.Equal(suite.T(), int64(0), captured.FromAccount.HeldAmount)
+	require.Equal(suite.T(), int64(800), captured.FromAccount.AvailableBalance)
+	require.Equal(suite.T(), int64(200), captured.ToAccount.Balance)
+
+	recorder = suite.captureHold(bankerAccessToken, hold.ID, captureParam)
+	require.Equal(suite.T(), http.StatusConflict, recorder.Result().StatusCode)
+}
+
+func (suite *HoldControllerTestSuite) TestReleaseAndExpireHold() {
+	registerUserParam := &dto.RegisterUserDto{
+		Email:     "Max@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Max",
+		LastName:  "Mustermann",
+	}
+
+	accessToken := registerUserAndLogin(registerUserParam, suite.router, suite.T())
+	account := createAccount(accessToken, "EUR", suite.router, suite.T())
+
+	_, err := testStore.SetAccountBalance(suite.ctx, account.ID, 1000)
+	require.NoError(suite.T(), err)
+
+	bankerAccessToken := registerBankerAndLogin("Tim@Mustermann.de", suite.router, suite.T())
+
+	var holds []db.Hold
+
+	for i := 0; i < 2; i++ {
+		recorder := suite.createHold(bankerAccessToken, account.ID, &dto.CreateHoldDto{Amount: 400})
+		require.Equal(suite.T(), http.StatusCreated, recorder.Result().StatusCode)
+
+		var hold db.Hold
+		err = json.NewDecoder(recorder.Result().Body).Decode(&hold)
+		require.NoError(suite.T(), err)
+
+		holds = append(holds, hold)
+	}
+
+	request := httptest.NewRequest("POST", fmt.Sprintf("/holds/%d/release", holds[0].ID), nil)
+	request.AddCookie(bankerAccessToken)
+	recorder := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusOK, recorder.Result().StatusCode)
+
+	var released db.Hold
+	err = json.NewDecoder(recorder.Result().Body).Decode(&released)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), db.HoldReleased, released.Status)
+
+	// released holds cannot be released again
+	request = httptest.NewRequest("POST", fmt.Sprintf("/holds/%d/release", holds[0].ID), nil)
+	request.AddCookie(bankerAccessToken)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusConflict, recorder.Result().StatusCode)
+
+	// holds are released by the expiry worker after their expiry time
+	count, err := suite.holdService.ExpireHolds(suite.ctx, time.Now())
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), 0, count)
+
+	count, err = suite.holdService.ExpireHolds(suite.ctx, holds[1].ExpiresAt.Add(time.Second))
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), 1, count)
+
+	request = httptest.NewRequest("GET", fmt.Sprintf("/holds/%d", holds[1].ID), nil)
+	request.AddCookie(accessToken)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusOK, recorder.Result().StatusCode)
+
+	var expired db.Hold
+	err = json.NewDecoder(recorder.Result().Body).Decode(&expired)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), db.HoldExpired, expired.Status)
+
+	updatedAccount, err := testStore.GetAccount(suite.ctx, account.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), int64(0), updatedAccount.HeldAmount)
+	require.Equal(suite.T(), int64(1000), updatedAccount.AvailableBalance)
+}
+
+func (suite *HoldControllerTestSuite) createHold(accessToken *http.Cookie, accountId int64, holdParam *dto.CreateHoldDto) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+	err := json.NewEncoder(&body).Encode(holdParam)
+	require.NoError(suite.T(), err)
+
+	request := httptest.NewRequest("POST", fmt.Sprintf("/accounts/%d/holds", accountId), &body)
+	request.AddCookie(accessToken)
+	recorder := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func (suite *HoldControllerTestSuite) captureHold(accessToken *http.Cookie, holdId int64, captureParam *dto.CaptureHoldDto) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+	err := json.NewEncoder(&body).Encode(captureParam)
+	require.NoError(suite.T(), err)
+
+	request := httptest.NewRequest("POST", fmt.Sprintf("/holds/%d/capture", holdId), &body)
+	request.AddCookie(accessToken)
+	recorder := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	return recorder
+}
@@ -0,0 +1,4 @@
+package rest
+
+// idempotencyKeyHeader lets clients retry POST requests without executing them twice
+const idempotencyKeyHeader = "Idempotency-Key"
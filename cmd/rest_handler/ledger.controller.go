@@ -0,0 +1,169 @@
+package rest
+
+import (
+	"encoding/json"
+	"kara-bank/dto"
+	"kara-bank/services"
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type LedgerController struct {
+	ledgerService services.LedgerServiceInterface
+	validator     *validator.Validate
+}
+
+func NewLedgerController(ledgerService services.LedgerServiceInterface, validator *validator.Validate) *LedgerController {
+	return &LedgerController{
+		ledgerService: ledgerService,
+		validator:     validator,
+	}
+}
+
+func (l *LedgerController) HandleCreateLedgerAccount(w http.ResponseWriter, r *http.Request) {
+	var requestBody dto.CreateLedgerAccountDto
+	err := json.NewDecoder(r.Body).Decode(&requestBody)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = l.validator.Struct(requestBody)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ledgerAccount, respErr := l.ledgerService.CreateLedgerAccount(r.Context(), &requestBody)
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	responseJson, err := json.Marshal(&ledgerAccount)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(responseJson)
+}
+
+func (l *LedgerController) HandleListLedgerAccounts(w http.ResponseWriter, r *http.Request) {
+	ledgerAccounts, respErr := l.ledgerService.ListLedgerAccounts(r.Context())
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	responseJson, err := json.Marshal(&ledgerAccounts)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseJson)
+}
+
+func (l *LedgerController) HandlePostJournal(w http.ResponseWriter, r *http.Request) {
+	var requestBody dto.PostJournalDto
+	err := json.NewDecoder(r.Body).Decode(&requestBody)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = l.validator.Struct(requestBody)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, respErr := l.ledgerService.PostJournal(r.Context(), &requestBody)
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	responseJson, err := json.Marshal(&result)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(responseJson)
+}
+
+func (l *LedgerController) HandleGetJournal(w http.ResponseWriter, r *http.Request) {
+	pathValue := r.PathValue("id")
+	id, err := strconv.Atoi(pathValue)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, respErr := l.ledgerService.GetJournal(r.Context(), int64(id))
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	responseJson, err := json.Marshal(&result)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseJson)
+}
+
+// HandleGetTrialBalance sums the postings per ledger account and currency, optionally only the postings before end_time
+func (l *LedgerController) HandleGetTrialBalance(w http.ResponseWriter, r *http.Request) {
+	endTime, err := parseTimeQuery(r.URL.Query(), "end_time")
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	trialBalance, respErr := l.ledgerService.GetTrialBalance(r.Context(), endTime)
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	responseJson, err := json.Marshal(&trialBalance)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseJson)
+}
@@ -0,0 +1,245 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	db "kara-bank/db/repositories"
+	"kara-bank/dto"
+	"kara-bank/middlewares"
+	"kara-bank/services"
+	"kara-bank/utils"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type LedgerControllerTestSuite struct {
+	suite.Suite
+	ctx    context.Context
+	router http.Handler
+}
+
+func TestLedgerSuite(t *testing.T) {
+	suite.Run(t, &LedgerControllerTestSuite{})
+}
+
+func (suite *LedgerControllerTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+	tokenMaker := newTestTokenMaker()
+	validatorObj := validator.New(validator.WithRequiredStructEnabled())
+
+	userService := services.NewUserService(testStore, tokenMaker)
+	userController := NewUserController(userService, validatorObj)
+
+	accountService := services.NewAccountService(testStore)
+	accountController := NewAccountController(accountService, validatorObj)
+
+	ledgerService := services.NewLedgerService(testStore)
+	ledgerController := NewLedgerController(ledgerService, validatorObj)
+
+	router := utils.NewRouteRegistry()
+
+	router.HandleFunc("POST /users/register", utils.PublicRoute(), userController.HandleRegisterUser)
+	router.HandleFunc("POST /users/login", utils.PublicRoute(), userController.HandleLoginUser)
+
+	router.HandleFunc("POST /accounts", utils.AllowRoles(utils.CustomerRole), accountController.HandleCreateAccount)
+
+	router.HandleFunc("GET /ledger/accounts", utils.AllowRoles(utils.BankerRole, utils.AdminRole), ledgerController.HandleListLedgerAccounts)
+	router.HandleFunc("POST /ledger/accounts", utils.AllowRoles(utils.AdminRole), ledgerController.HandleCreateLedgerAccount)
+	router.HandleFunc("POST /ledger/journals", utils.AllowRoles(utils.AdminRole), ledgerController.HandlePostJournal)
+	router.HandleFunc("GET /ledger/journals/{id}", utils.AllowRoles(utils.BankerRole, utils.AdminRole), ledgerController.HandleGetJournal)
+	router.HandleFunc("GET /ledger/trial-balance", utils.AllowRoles(utils.BankerRole, utils.AdminRole), ledgerController.HandleGetTrialBalance)
+
+	routerWithMiddleware := middlewares.AuthMiddleware(tokenMaker, userService, router)
+
+	suite.router = routerWithMiddleware
+}
+
+func (suite *LedgerControllerTestSuite) AfterTest(suiteName string, testName string) {
+	// clear tables after every test to avoid dependencies and side effects between tests
+	_, err := testStore.ClearEntriesTable()
+	require.NoError(suite.T(), err)
+
+	_, err = testStore.ClearPostingsTable()
+	require.NoError(suite.T(), err)
+
+	_, err = testStore.ClearJournalsTable()
+	require.NoError(suite.T(), err)
+
+	_, err = testStore.ClearAccountsTable()
+	require.NoError(suite.T(), err)
+
+	_, err = testStore.ClearSessionsTable()
+	require.NoError(suite.T(), err)
+
+	_, err = testStore.ClearUsersTable()
+	require.NoError(suite.T(), err)
+}
+
+func (suite *LedgerControllerTestSuite) TestPostJournal() {
+	registerUserParam := &dto.RegisterUserDto{
+		Email:     "Max@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Max",
+		LastName:  "Mustermann",
+	}
+
+	accessToken := registerUserAndLogin(registerUserParam, suite.router, suite.T())
+	account := createAccount(accessToken, "EUR", suite.router, suite.T())
+
+	_, err := testStore.SetAccountBalance(suite.ctx, account.ID, 1000)
+	require.NoError(suite.T(), err)
+
+	adminAccessToken := registerStaffAndLogin("Ada@Mustermann.de", utils.AdminRole, suite.router, suite.T())
+	bankerAccessToken := registerStaffAndLogin("Tim@Mustermann.de", utils.BankerRole, suite.router, suite.T())
+
+	fee := &dto.PostJournalDto{
+		Description: "account fee",
+		Postings: []dto.JournalPosting{
+			{LedgerAccount: db.LedgerCustomerDeposits, AccountId: &account.ID, Amount: 50, Currency: "EUR"},
+			{LedgerAccount: db.LedgerFeeIncome, Amount: -50, Currency: "EUR"},
+		},
+	}
+
+	// only admins post manual journals
+	recorder := suite.postJournal(bankerAccessToken, fee)
+	require.Equal(suite.T(), http.StatusUnauthorized, recorder.Result().StatusCode)
+
+	recorder = suite.postJournal(adminAccessToken, fee)
+	require.Equal(suite.T(), http.StatusCreated, recorder.Result().StatusCode)
+
+	var posted db.JournalTxResult
+	err = json.NewDecoder(recorder.Result().Body).Decode(&posted)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), posted.Postings, 2)
+
+	updatedAccount, err := testStore.GetAccount(suite.ctx, account.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), int64(950), updatedAccount.Balance)
+
+	request := httptest.NewRequest("GET", fmt.Sprintf("/ledger/journals/%d", posted.Journal.ID), nil)
+	request.AddCookie(bankerAccessToken)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusOK, recorder.Result().StatusCode)
+
+	var journal db.JournalTxResult
+	err = json.NewDecoder(recorder.Result().Body).Decode(&journal)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "account fee", journal.Journal.Description)
+	require.Equal(suite.T(), posted.Postings[0].ID, journal.Postings[0].ID)
+
+	request = httptest.NewRequest("GET", "/ledger/trial-balance", nil)
+	request.AddCookie(bankerAccessToken)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusOK, recorder.Result().StatusCode)
+
+	var trialBalance []db.GetTrialBalanceRow
+	err = json.NewDecoder(recorder.Result().Body).Decode(&trialBalance)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), trialBalance, 2)
+	require.Equal(suite.T(), db.LedgerCustomerDeposits, trialBalance[0].LedgerAccount)
+	require.Equal(suite.T(), int64(50), trialBalance[0].Debit)
+	require.Equal(suite.T(), db.LedgerFeeIncome, trialBalance[1].LedgerAccount)
+	require.Equal(suite.T(), int64(50), trialBalance[1].Credit)
+
+	// postings after the end time are not part of the trial balance
+	endTime := posted.Journal.CreatedAt.Add(-time.Second).UTC().Format(time.RFC3339)
+	request = httptest.NewRequest("GET", "/ledger/trial-balance?end_time="+endTime, nil)
+	request.AddCookie(bankerAccessToken)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusOK, recorder.Result().StatusCode)
+
+	err = json.NewDecoder(recorder.Result().Body).Decode(&trialBalance)
+	require.NoError(suite.T(), err)
+	require.Empty(suite.T(), trialBalance)
+}
+
+func (suite *LedgerControllerTestSuite) TestPostJournalInvalid() {
+	registerUserParam := &dto.RegisterUserDto{
+		Email:     "Max@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Max",
+		LastName:  "Mustermann",
+	}
+
+	accessToken := registerUserAndLogin(registerUserParam, suite.router, suite.T())
+	account := createAccount(accessToken, "EUR", suite.router, suite.T())
+	adminAccessToken := registerStaffAndLogin("Ada@Mustermann.de", utils.AdminRole, suite.router, suite.T())
+
+	journals := []struct {
+		postings       []dto.JournalPosting
+		expectedStatus int
+	}{
+		// a single posting cannot be balanced
+		{
+			postings: []dto.JournalPosting{
+				{LedgerAccount: db.LedgerCash, Amount: 50, Currency: "EUR"},
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			postings: []dto.JournalPosting{
+				{LedgerAccount: db.LedgerCash, Amount: 50, Currency: "EUR"},
+				{LedgerAccount: db.LedgerEquity, Amount: -40, Currency: "EUR"},
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		// customer accounts are only booked on the customer deposits
+		{
+			postings: []dto.JournalPosting{
+				{LedgerAccount: db.LedgerCash, AccountId: &account.ID, Amount: 50, Currency: "EUR"},
+				{LedgerAccount: db.LedgerEquity, Amount: -50, Currency: "EUR"},
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			postings: []dto.JournalPosting{
+				{LedgerAccount: "9999", Amount: 50, Currency: "EUR"},
+				{LedgerAccount: db.LedgerEquity, Amount: -50, Currency: "EUR"},
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		// the new account has no money for a debit
+		{
+			postings: []dto.JournalPosting{
+				{LedgerAccount: db.LedgerCustomerDeposits, AccountId: &account.ID, Amount: 50, Currency: "EUR"},
+				{LedgerAccount: db.LedgerFeeIncome, Amount: -50, Currency: "EUR"},
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, journal := range journals {
+		recorder := suite.postJournal(adminAccessToken, &dto.PostJournalDto{
+			Description: "invalid journal",
+			Postings:    journal.postings,
+		})
+		require.Equal(suite.T(), journal.expectedStatus, recorder.Result().StatusCode)
+	}
+}
+
+func (suite *LedgerControllerTestSuite) postJournal(accessToken *http.Cookie, journalParam *dto.PostJournalDto) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+	err := json.NewEncoder(&body).Encode(journalParam)
+	require.NoError(suite.T(), err)
+
+	request := httptest.NewRequest("POST", "/ledger/journals", &body)
+	request.AddCookie(accessToken)
+	recorder := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	return recorder
+}
@@ -0,0 +1,98 @@
+package rest
+
+import (
+	"fmt"
+	"kara-bank/dto"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// pageQuery holds the cursor pagination query parameters of the list endpoints
+type pageQuery struct {
+	limit  int32
+	cursor string
+}
+
+// historyQuery holds the pagination and filter query parameters of the transfer and entry history
+type historyQuery struct {
+	pageQuery
+	startTime *time.Time
+	endTime   *time.Time
+	direction string
+}
+
+// parsePageQuery reads limit and cursor from the query string. Without a limit a page has dto.DefaultPageSize items
+func parsePageQuery(query url.Values) (*pageQuery, error) {
+	limit, err := parseInt32Query(query, "limit")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &pageQuery{
+		limit:  dto.PageSize(limit),
+		cursor: query.Get("cursor"),
+	}, nil
+}
+
+// parseHistoryQuery reads limit, cursor, start_time, end_time and direction from the query string.
+// Times are expected in RFC 3339 format, e.g. 2024-01-31T00:00:00Z.
+func parseHistoryQuery(query url.Values) (*historyQuery, error) {
+	page, err := parsePageQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &historyQuery{
+		pageQuery: *page,
+		direction: query.Get("direction"),
+	}
+
+	result.startTime, err = parseTimeQuery(query, "start_time")
+
+	if err != nil {
+		return nil, err
+	}
+
+	result.endTime, err = parseTimeQuery(query, "end_time")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func parseInt32Query(query url.Values, key string) (int32, error) {
+	value := query.Get(key)
+
+	if value == "" {
+		return 0, nil
+	}
+
+	number, err := strconv.ParseInt(value, 10, 32)
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", key, err)
+	}
+
+	return int32(number), nil
+}
+
+func parseTimeQuery(query url.Values, key string) (*time.Time, error) {
+	value := query.Get(key)
+
+	if value == "" {
+		return nil, nil
+	}
+
+	parsedTime, err := time.Parse(time.RFC3339, value)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", key, err)
+	}
+
+	return &parsedTime, nil
+}
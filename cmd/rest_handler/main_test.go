@@ -4,10 +4,13 @@ import (
 	"context"
 	db "kara-bank/db/repositories"
 	"kara-bank/testcontainer"
+	"kara-bank/utils"
 	"log"
 	"os"
 	"path/filepath"
 	"testing"
+
+	"aidanwoods.dev/go-paseto"
 )
 
 var testStore db.Store
@@ -24,3 +27,16 @@ func TestMain(m *testing.M) {
 
 	os.Exit(m.Run())
 }
+
+// newTestTokenMaker creates a token maker with a random key that is only valid for the current test run
+func newTestTokenMaker() utils.TokenMaker {
+	tokenMaker, err := utils.NewPasetoMaker("test", map[string]paseto.V4SymmetricKey{
+		"test": paseto.NewV4SymmetricKey(),
+	})
+
+	if err != nil {
+		log.Fatalf("Could not create token maker: %v", err)
+	}
+
+	return tokenMaker
+}
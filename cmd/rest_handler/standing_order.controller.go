@@ -0,0 +1,242 @@
+package rest
+
+import (
+	"encoding/json"
+	"kara-bank/dto"
+	"kara-bank/middlewares"
+	"kara-bank/services"
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type StandingOrderController struct {
+	standingOrderService services.StandingOrderServiceInterface
+	validator            *validator.Validate
+}
+
+func NewStandingOrderController(standingOrderService services.StandingOrderServiceInterface, validator *validator.Validate) *StandingOrderController {
+	return &StandingOrderController{
+		standingOrderService: standingOrderService,
+		validator:            validator,
+	}
+}
+
+func (s *StandingOrderController) HandleCreateStandingOrder(w http.ResponseWriter, r *http.Request) {
+	var requestBody dto.CreateStandingOrderDto
+	err := json.NewDecoder(r.Body).Decode(&requestBody)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	email, ok := r.Context().Value(middlewares.ContextUserEmailKey).(string)
+
+	if !ok {
+		http.Error(w, "Could not extract email from token", http.StatusInternalServerError)
+		return
+	}
+
+	requestBody.Owner = email
+	err = s.validator.Struct(requestBody)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	standingOrder, respErr := s.standingOrderService.CreateStandingOrder(r.Context(), &requestBody)
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	responseJson, err := json.Marshal(&standingOrder)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(responseJson)
+}
+
+func (s *StandingOrderController) HandleGetStandingOrder(w http.ResponseWriter, r *http.Request) {
+	pathValue := r.PathValue("id")
+	id, err := strconv.Atoi(pathValue)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	email, ok := r.Context().Value(middlewares.ContextUserEmailKey).(string)
+
+	if !ok {
+		http.Error(w, "Could not convert email from token to string", http.StatusInternalServerError)
+		return
+	}
+
+	role, ok := r.Context().Value(middlewares.ContextUserRoleKey).(string)
+
+	if !ok {
+		http.Error(w, "Could not convert role from token to string", http.StatusInternalServerError)
+		return
+	}
+
+	standingOrder, respErr := s.standingOrderService.GetStandingOrder(r.Context(), int64(id), email, role)
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	responseJson, err := json.Marshal(&standingOrder)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseJson)
+}
+
+func (s *StandingOrderController) HandleListStandingOrders(w http.ResponseWriter, r *http.Request) {
+	query, err := parsePageQuery(r.URL.Query())
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	args := dto.ListStandingOrdersDto{
+		Limit:  query.limit,
+		Cursor: query.cursor,
+	}
+
+	err = s.validator.Struct(args)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	email, ok := r.Context().Value(middlewares.ContextUserEmailKey).(string)
+
+	if !ok {
+		http.Error(w, "Could not convert email from token to string", http.StatusInternalServerError)
+		return
+	}
+
+	standingOrders, respErr := s.standingOrderService.ListStandingOrders(r.Context(), &args, email)
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	responseJson, err := json.Marshal(&standingOrders)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseJson)
+}
+
+func (s *StandingOrderController) HandleUpdateStandingOrder(w http.ResponseWriter, r *http.Request) {
+	pathValue := r.PathValue("id")
+	id, err := strconv.Atoi(pathValue)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var requestBody dto.UpdateStandingOrderDto
+	err = json.NewDecoder(r.Body).Decode(&requestBody)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	email, ok := r.Context().Value(middlewares.ContextUserEmailKey).(string)
+
+	if !ok {
+		http.Error(w, "Could not convert email from token to string", http.StatusInternalServerError)
+		return
+	}
+
+	requestBody.Id = int64(id)
+	requestBody.Owner = email
+	err = s.validator.Struct(requestBody)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	standingOrder, respErr := s.standingOrderService.UpdateStandingOrder(r.Context(), &requestBody)
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	responseJson, err := json.Marshal(&standingOrder)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseJson)
+}
+
+func (s *StandingOrderController) HandleCancelStandingOrder(w http.ResponseWriter, r *http.Request) {
+	pathValue := r.PathValue("id")
+	id, err := strconv.Atoi(pathValue)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	email, ok := r.Context().Value(middlewares.ContextUserEmailKey).(string)
+
+	if !ok {
+		http.Error(w, "Could not convert email from token to string", http.StatusInternalServerError)
+		return
+	}
+
+	standingOrder, respErr := s.standingOrderService.CancelStandingOrder(r.Context(), int64(id), email)
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	responseJson, err := json.Marshal(&standingOrder)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseJson)
+}
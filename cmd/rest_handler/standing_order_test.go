@@ -0,0 +1,401 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	db "kara-bank/db/repositories"
+	"kara-bank/dto"
+	"kara-bank/middlewares"
+	"kara-bank/services"
+	"kara-bank/utils"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type StandingOrderControllerTestSuite struct {
+	suite.Suite
+	ctx                  context.Context
+	router               http.Handler
+	standingOrderService *services.StandingOrderServiceImpl
+}
+
+func TestStandingOrderSuite(t *testing.T) {
+	suite.Run(t, &StandingOrderControllerTestSuite{})
+}
+
+func (suite *StandingOrderControllerTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+	tokenMaker := newTestTokenMaker()
+	validatorObj := validator.New(validator.WithRequiredStructEnabled())
+
+	userService := services.NewUserService(testStore, tokenMaker)
+	userController := NewUserController(userService, validatorObj)
+
+	accountService := services.NewAccountService(testStore)
+	accountController := NewAccountController(accountService, validatorObj)
+
+	transferService := services.NewTransferService(testStore, utils.NewStaticExchangeRateProvider(map[string]utils.ExchangeRate{}), 0)
+
+	suite.standingOrderService = services.NewStandingOrderService(testStore, transferService)
+	standingOrderController := NewStandingOrderController(suite.standingOrderService, validatorObj)
+
+	router := utils.NewRouteRegistry()
+
+	router.HandleFunc("POST /users/register", utils.PublicRoute(), userController.HandleRegisterUser)
+	router.HandleFunc("POST /users/login", utils.PublicRoute(), userController.HandleLoginUser)
+
+	router.HandleFunc("POST /accounts", utils.AllowRoles(utils.CustomerRole), accountController.HandleCreateAccount)
+
+	router.HandleFunc("POST /standing-orders", utils.AllowRoles(utils.CustomerRole), standingOrderController.HandleCreateStandingOrder)
+	router.HandleFunc("GET /standing-orders", utils.AllowRoles(utils.CustomerRole), standingOrderController.HandleListStandingOrders)
+	router.HandleFunc("GET /standing-orders/{id}", utils.AllowRoles(utils.AllRoles...), standingOrderController.HandleGetStandingOrder)
+	router.HandleFunc("PUT /standing-orders/{id}", utils.AllowRoles(utils.CustomerRole), standingOrderController.HandleUpdateStandingOrder)
+	router.HandleFunc("DELETE /standing-orders/{id}", utils.AllowRoles(utils.CustomerRole), standingOrderController.HandleCancelStandingOrder)
+
+	routerWithMiddleware := middlewares.AuthMiddleware(tokenMaker, userService, router)
+
+	suite.router = routerWithMiddleware
+}
+
+func (suite *StandingOrderControllerTestSuite) AfterTest(suiteName string, testName string) {
+	// clear tables after every test to avoid dependencies and side effects between tests
+	_, err := testStore.ClearStandingOrdersTable()
+	require.NoError(suite.T(), err)
+
+	_, err = testStore.ClearEntriesTable()
+	require.NoError(suite.T(), err)
+
+	_, err = testStore.ClearPostingsTable()
+	require.NoError(suite.T(), err)
+
+	_, err = testStore.ClearJournalsTable()
+	require.NoError(suite.T(), err)
+
+	_, err = testStore.ClearTransfersTable()
+	require.NoError(suite.T(), err)
+
+	_, err = testStore.ClearAccountsTable()
+	require.NoError(suite.T(), err)
+
+	_, err = testStore.ClearSessionsTable()
+	require.NoError(suite.T(), err)
+
+	_, err = testStore.ClearUsersTable()
+	require.NoError(suite.T(), err)
+}
+
+func (suite *StandingOrderControllerTestSuite) TestCreateAndExecuteStandingOrder() {
+	registerUserParam1 := &dto.RegisterUserDto{
+		Email:     "Max@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Max",
+		LastName:  "Mustermann",
+	}
+
+	accessToken1 := registerUserAndLogin(registerUserParam1, suite.router, suite.T())
+	account1 := createAccount(accessToken1, "EUR", suite.router, suite.T())
+
+	_, err := testStore.SetAccountBalance(suite.ctx, account1.ID, 100)
+	require.NoError(suite.T(), err)
+
+	registerUserParam2 := &dto.RegisterUserDto{
+		Email:     "Tom@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Tom",
+		LastName:  "Mustermann",
+	}
+
+	accessToken2 := registerUserAndLogin(registerUserParam2, suite.router, suite.T())
+	account2 := createAccount(accessToken2, "EUR", suite.router, suite.T())
+
+	standingOrder := createStandingOrder(accessToken1, account1.ID, account2.ID, 30, utils.FrequencyMonthly, suite.router, suite.T())
+	require.Equal(suite.T(), "active", standingOrder.Status)
+	require.NotNil(suite.T(), standingOrder.NextExecutionAt)
+	require.Equal(suite.T(), int32(0), standingOrder.ExecutionCount)
+
+	// the first execution is due right away
+	count, err := suite.standingOrderService.ExecuteDueStandingOrders(suite.ctx, time.Now())
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), 1, count)
+
+	executed, err := testStore.GetStandingOrder(suite.ctx, standingOrder.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "active", executed.Status)
+	require.Equal(suite.T(), int32(1), executed.ExecutionCount)
+	require.NotNil(suite.T(), executed.LastTransferID)
+
+	nextExecutionAt, ok := utils.ScheduledExecution(standingOrder.StartAt, utils.FrequencyMonthly, 1)
+	require.True(suite.T(), ok)
+	require.WithinDuration(suite.T(), nextExecutionAt, *executed.NextExecutionAt, time.Second)
+
+	updatedAccount1, err := testStore.GetAccount(suite.ctx, account1.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), int64(70), updatedAccount1.Balance)
+
+	updatedAccount2, err := testStore.GetAccount(suite.ctx, account2.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), int64(30), updatedAccount2.Balance)
+
+	// the next execution is not due yet
+	count, err = suite.standingOrderService.ExecuteDueStandingOrders(suite.ctx, time.Now())
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), 0, count)
+}
+
+func (suite *StandingOrderControllerTestSuite) TestExecuteStandingOrderInsufficientFunds() {
+	registerUserParam1 := &dto.RegisterUserDto{
+		Email:     "Max@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Max",
+		LastName:  "Mustermann",
+	}
+
+	accessToken1 := registerUserAndLogin(registerUserParam1, suite.router, suite.T())
+	account1 := createAccount(accessToken1, "EUR", suite.router, suite.T())
+	account2 := createAccount(accessToken1, "EUR", suite.router, suite.T())
+
+	standingOrder := createStandingOrder(accessToken1, account1.ID, account2.ID, 30, utils.FrequencyOnce, suite.router, suite.T())
+
+	now := time.Now()
+	count, err := suite.standingOrderService.ExecuteDueStandingOrders(suite.ctx, now)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), 1, count)
+
+	// the execution is retried later
+	failed, err := testStore.GetStandingOrder(suite.ctx, standingOrder.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "active", failed.Status)
+	require.Equal(suite.T(), int32(0), failed.ExecutionCount)
+	require.Equal(suite.T(), int32(1), failed.FailedAttempts)
+	require.NotNil(suite.T(), failed.LastError)
+	require.Nil(suite.T(), failed.LastTransferID)
+	require.True(suite.T(), failed.NextExecutionAt.After(now))
+
+	// the retry succeeds once the account is funded and completes the order
+	_, err = testStore.SetAccountBalance(suite.ctx, account1.ID, 100)
+	require.NoError(suite.T(), err)
+
+	count, err = suite.standingOrderService.ExecuteDueStandingOrders(suite.ctx, *failed.NextExecutionAt)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), 1, count)
+
+	completed, err := testStore.GetStandingOrder(suite.ctx, standingOrder.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "completed", completed.Status)
+	require.Equal(suite.T(), int32(1), completed.ExecutionCount)
+	require.Equal(suite.T(), int32(0), completed.FailedAttempts)
+	require.Nil(suite.T(), completed.NextExecutionAt)
+	require.NotNil(suite.T(), completed.LastTransferID)
+}
+
+func (suite *StandingOrderControllerTestSuite) TestUpdateAndCancelStandingOrder() {
+	registerUserParam1 := &dto.RegisterUserDto{
+		Email:     "Max@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Max",
+		LastName:  "Mustermann",
+	}
+
+	accessToken1 := registerUserAndLogin(registerUserParam1, suite.router, suite.T())
+	account1 := createAccount(accessToken1, "EUR", suite.router, suite.T())
+	account2 := createAccount(accessToken1, "EUR", suite.router, suite.T())
+
+	standingOrder := createStandingOrder(accessToken1, account1.ID, account2.ID, 30, utils.FrequencyWeekly, suite.router, suite.T())
+
+	// change the amount
+	updateParam := &dto.UpdateStandingOrderDto{
+		Amount: 50,
+	}
+
+	var body bytes.Buffer
+	err := json.NewEncoder(&body).Encode(updateParam)
+	require.NoError(suite.T(), err)
+
+	request := httptest.NewRequest("PUT", fmt.Sprintf("/standing-orders/%d", standingOrder.ID), &body)
+	request.AddCookie(accessToken1)
+	recorder := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusOK, recorder.Result().StatusCode)
+
+	var updated db.StandingOrder
+	err = json.NewDecoder(recorder.Result().Body).Decode(&updated)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), int64(50), updated.Amount)
+	require.Equal(suite.T(), "active", updated.Status)
+
+	// list the standing orders of the user
+	request = httptest.NewRequest("GET", "/standing-orders?limit=10", nil)
+	request.AddCookie(accessToken1)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusOK, recorder.Result().StatusCode)
+
+	var page dto.PageDto[*db.StandingOrder]
+	err = json.NewDecoder(recorder.Result().Body).Decode(&page)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), page.Items, 1)
+	require.Equal(suite.T(), standingOrder.ID, page.Items[0].ID)
+
+	// cancel the standing order
+	request = httptest.NewRequest("DELETE", fmt.Sprintf("/standing-orders/%d", standingOrder.ID), nil)
+	request.AddCookie(accessToken1)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusOK, recorder.Result().StatusCode)
+
+	var cancelled db.StandingOrder
+	err = json.NewDecoder(recorder.Result().Body).Decode(&cancelled)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), "cancelled", cancelled.Status)
+	require.Nil(suite.T(), cancelled.NextExecutionAt)
+
+	// cancelled orders are not executed and cannot be changed anymore
+	count, err := suite.standingOrderService.ExecuteDueStandingOrders(suite.ctx, time.Now())
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), 0, count)
+
+	request = httptest.NewRequest("DELETE", fmt.Sprintf("/standing-orders/%d", standingOrder.ID), nil)
+	request.AddCookie(accessToken1)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusConflict, recorder.Result().StatusCode)
+}
+
+func (suite *StandingOrderControllerTestSuite) TestStandingOrderOfOtherUser() {
+	registerUserParam1 := &dto.RegisterUserDto{
+		Email:     "Max@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Max",
+		LastName:  "Mustermann",
+	}
+
+	accessToken1 := registerUserAndLogin(registerUserParam1, suite.router, suite.T())
+	account1 := createAccount(accessToken1, "EUR", suite.router, suite.T())
+
+	registerUserParam2 := &dto.RegisterUserDto{
+		Email:     "Tom@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Tom",
+		LastName:  "Mustermann",
+	}
+
+	accessToken2 := registerUserAndLogin(registerUserParam2, suite.router, suite.T())
+	account2 := createAccount(accessToken2, "EUR", suite.router, suite.T())
+
+	standingOrder := createStandingOrder(accessToken1, account1.ID, account2.ID, 30, utils.FrequencyDaily, suite.router, suite.T())
+
+	// the receiver cannot see or change the standing order
+	testCases := []struct {
+		name   string
+		method string
+		body   string
+	}{
+		{"Get", "GET", ""},
+		{"Update", "PUT", `{"amount": 1}`},
+		{"Cancel", "DELETE", ""},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			request := httptest.NewRequest(testCase.method, fmt.Sprintf("/standing-orders/%d", standingOrder.ID), bytes.NewBufferString(testCase.body))
+			request.AddCookie(accessToken2)
+			recorder := httptest.NewRecorder()
+
+			suite.router.ServeHTTP(recorder, request)
+			require.Equal(suite.T(), http.StatusUnauthorized, recorder.Result().StatusCode)
+		})
+	}
+
+	// standing orders can only be created for own accounts
+	createParam := &dto.CreateStandingOrderDto{
+		FromAccountId: account1.ID,
+		ToAccountId:   account2.ID,
+		Amount:        30,
+		Frequency:     utils.FrequencyDaily,
+	}
+
+	var body bytes.Buffer
+	err := json.NewEncoder(&body).Encode(createParam)
+	require.NoError(suite.T(), err)
+
+	request := httptest.NewRequest("POST", "/standing-orders", &body)
+	request.AddCookie(accessToken2)
+	recorder := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusUnauthorized, recorder.Result().StatusCode)
+}
+
+func (suite *StandingOrderControllerTestSuite) TestCreateStandingOrderInvalidInput() {
+	registerUserParam := &dto.RegisterUserDto{
+		Email:     "Max@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Max",
+		LastName:  "Mustermann",
+	}
+
+	accessToken := registerUserAndLogin(registerUserParam, suite.router, suite.T())
+	account1 := createAccount(accessToken, "EUR", suite.router, suite.T())
+	account2 := createAccount(accessToken, "EUR", suite.router, suite.T())
+
+	testCases := []struct {
+		name string
+		body string
+	}{
+		{"InvalidFrequency", fmt.Sprintf(`{"from_account_id": %d, "to_account_id": %d, "amount": 30, "frequency": "yearly"}`, account1.ID, account2.ID)},
+		{"SameAccount", fmt.Sprintf(`{"from_account_id": %d, "to_account_id": %d, "amount": 30, "frequency": "daily"}`, account1.ID, account1.ID)},
+		{"NegativeAmount", fmt.Sprintf(`{"from_account_id": %d, "to_account_id": %d, "amount": -30, "frequency": "daily"}`, account1.ID, account2.ID)},
+		{"StartInPast", fmt.Sprintf(`{"from_account_id": %d, "to_account_id": %d, "amount": 30, "frequency": "daily", "start_at": "2020-01-01T00:00:00Z"}`, account1.ID, account2.ID)},
+		{"EndBeforeStart", fmt.Sprintf(`{"from_account_id": %d, "to_account_id": %d, "amount": 30, "frequency": "daily", "start_at": "2999-02-01T00:00:00Z", "end_at": "2999-01-01T00:00:00Z"}`, account1.ID, account2.ID)},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			request := httptest.NewRequest("POST", "/standing-orders", bytes.NewBufferString(testCase.body))
+			request.AddCookie(accessToken)
+			recorder := httptest.NewRecorder()
+
+			suite.router.ServeHTTP(recorder, request)
+			require.Equal(suite.T(), http.StatusBadRequest, recorder.Result().StatusCode)
+		})
+	}
+}
+
+// helper function for tests that need standing orders, the first execution is due right away
+func createStandingOrder(accessToken *http.Cookie, fromAccountId int64, toAccountId int64, amount int64, frequency string, router http.Handler, t *testing.T) *db.StandingOrder {
+	standingOrderParam := &dto.CreateStandingOrderDto{
+		FromAccountId: fromAccountId,
+		ToAccountId:   toAccountId,
+		Amount:        amount,
+		Frequency:     frequency,
+	}
+
+	var body bytes.Buffer
+	err := json.NewEncoder(&body).Encode(standingOrderParam)
+	require.NoError(t, err)
+
+	request := httptest.NewRequest("POST", "/standing-orders", &body)
+	request.AddCookie(accessToken)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusCreated, recorder.Result().StatusCode)
+
+	var result db.StandingOrder
+	err = json.NewDecoder(recorder.Result().Body).Decode(&result)
+	require.NoError(t, err)
+
+	return &result
+}
@@ -0,0 +1,39 @@
+package rest
+
+import (
+	"encoding/json"
+	"kara-bank/utils"
+	"net/http"
+)
+
+type TokenController struct {
+	tokenMaker utils.TokenMaker
+}
+
+func NewTokenController(tokenMaker utils.TokenMaker) *TokenController {
+	return &TokenController{
+		tokenMaker: tokenMaker,
+	}
+}
+
+func (t *TokenController) HandleGetPublicKeys(w http.ResponseWriter, r *http.Request) {
+	publicKeyProvider, ok := t.tokenMaker.(utils.PublicKeyProvider)
+
+	if !ok {
+		http.Error(w, "Tokens are not signed with public keys", http.StatusNotFound)
+		return
+	}
+
+	responseJson, err := json.Marshal(map[string][]*utils.PublicKey{
+		"keys": publicKeyProvider.PublicKeys(),
+	})
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseJson)
+}
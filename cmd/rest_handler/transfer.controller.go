@@ -2,10 +2,13 @@ package rest
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"kara-bank/dto"
 	"kara-bank/middlewares"
 	"kara-bank/services"
 	"net/http"
+	"strconv"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -39,6 +42,7 @@ func (t *TransferController) HandleCreateTransfer(w http.ResponseWriter, r *http
 	}
 
 	requestBody.FromUser = email
+	requestBody.IdempotencyKey = r.Header.Get(idempotencyKeyHeader)
 	err = t.validator.Struct(requestBody)
 
 	if err != nil {
@@ -60,7 +64,317 @@ func (t *TransferController) HandleCreateTransfer(w http.ResponseWriter, r *http
 		return
 	}
 
+	// transfers that wait for an approval are not executed yet
+	status := http.StatusCreated
+
+	if transfer.Approval != nil {
+		status = http.StatusAccepted
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(responseJson)
+}
+
+func (t *TransferController) HandleReverseTransfer(w http.ResponseWriter, r *http.Request) {
+	pathValue := r.PathValue("id")
+	id, err := strconv.Atoi(pathValue)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// the body is optional, the remaining amount is reversed without it
+	var requestBody dto.ReverseTransferDto
+	err = json.NewDecoder(r.Body).Decode(&requestBody)
+
+	if err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	requestBody.TransferId = int64(id)
+	err = t.validator.Struct(requestBody)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, respErr := t.transferService.ReverseTransfer(r.Context(), &requestBody)
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	responseJson, err := json.Marshal(&result)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	w.Write(responseJson)
 }
+
+func (t *TransferController) HandleGetTransfer(w http.ResponseWriter, r *http.Request) {
+	pathValue := r.PathValue("id")
+	id, err := strconv.Atoi(pathValue)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	email, ok := r.Context().Value(middlewares.ContextUserEmailKey).(string)
+
+	if !ok {
+		http.Error(w, "Could not convert email from token to string", http.StatusInternalServerError)
+		return
+	}
+
+	role, ok := r.Context().Value(middlewares.ContextUserRoleKey).(string)
+
+	if !ok {
+		http.Error(w, "Could not convert role from token to string", http.StatusInternalServerError)
+		return
+	}
+
+	transfer, respErr := t.transferService.GetTransfer(r.Context(), int64(id), email, role)
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	responseJson, err := json.Marshal(&transfer)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseJson)
+}
+
+func (t *TransferController) HandleListTransfers(w http.ResponseWriter, r *http.Request) {
+	pathValue := r.PathValue("id")
+	id, err := strconv.Atoi(pathValue)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	query, err := parseHistoryQuery(r.URL.Query())
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	args := dto.ListTransfersDto{
+		AccountId: int64(id),
+		Limit:     query.limit,
+		Cursor:    query.cursor,
+		StartTime: query.startTime,
+		EndTime:   query.endTime,
+		Direction: query.direction,
+	}
+
+	err = t.validator.Struct(args)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	email, ok := r.Context().Value(middlewares.ContextUserEmailKey).(string)
+
+	if !ok {
+		http.Error(w, "Could not convert email from token to string", http.StatusInternalServerError)
+		return
+	}
+
+	role, ok := r.Context().Value(middlewares.ContextUserRoleKey).(string)
+
+	if !ok {
+		http.Error(w, "Could not convert role from token to string", http.StatusInternalServerError)
+		return
+	}
+
+	transfers, respErr := t.transferService.ListTransfers(r.Context(), &args, email, role)
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	responseJson, err := json.Marshal(&transfers)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseJson)
+}
+
+func (t *TransferController) HandleGetTransferApproval(w http.ResponseWriter, r *http.Request) {
+	pathValue := r.PathValue("id")
+	id, err := strconv.Atoi(pathValue)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	email, ok := r.Context().Value(middlewares.ContextUserEmailKey).(string)
+
+	if !ok {
+		http.Error(w, "Could not convert email from token to string", http.StatusInternalServerError)
+		return
+	}
+
+	role, ok := r.Context().Value(middlewares.ContextUserRoleKey).(string)
+
+	if !ok {
+		http.Error(w, "Could not convert role from token to string", http.StatusInternalServerError)
+		return
+	}
+
+	approval, respErr := t.transferService.GetTransferApproval(r.Context(), int64(id), email, role)
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	responseJson, err := json.Marshal(&approval)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseJson)
+}
+
+func (t *TransferController) HandleListTransferApprovals(w http.ResponseWriter, r *http.Request) {
+	query, err := parsePageQuery(r.URL.Query())
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	args := dto.ListTransferApprovalsDto{
+		Limit:  query.limit,
+		Cursor: query.cursor,
+	}
+
+	err = t.validator.Struct(args)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	approvals, respErr := t.transferService.ListPendingTransferApprovals(r.Context(), &args)
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	responseJson, err := json.Marshal(&approvals)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseJson)
+}
+
+func (t *TransferController) HandleApproveTransfer(w http.ResponseWriter, r *http.Request) {
+	pathValue := r.PathValue("id")
+	id, err := strconv.Atoi(pathValue)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	email, ok := r.Context().Value(middlewares.ContextUserEmailKey).(string)
+
+	if !ok {
+		http.Error(w, "Could not convert email from token to string", http.StatusInternalServerError)
+		return
+	}
+
+	result, respErr := t.transferService.ApproveTransfer(r.Context(), int64(id), email)
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	responseJson, err := json.Marshal(&result)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseJson)
+}
+
+func (t *TransferController) HandleRejectTransfer(w http.ResponseWriter, r *http.Request) {
+	pathValue := r.PathValue("id")
+	id, err := strconv.Atoi(pathValue)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	email, ok := r.Context().Value(middlewares.ContextUserEmailKey).(string)
+
+	if !ok {
+		http.Error(w, "Could not convert email from token to string", http.StatusInternalServerError)
+		return
+	}
+
+	approval, respErr := t.transferService.RejectTransfer(r.Context(), int64(id), email)
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	responseJson, err := json.Marshal(&approval)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseJson)
+}
@@ -4,19 +4,27 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	db "kara-bank/db/repositories"
 	"kara-bank/dto"
 	"kara-bank/middlewares"
 	"kara-bank/services"
 	"kara-bank/utils"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
 )
 
+// transfers above this amount must be approved by a banker
+const testApprovalThreshold = 1000
+
 type TransferControllerTestSuite struct {
 	suite.Suite
 	ctx    context.Context
@@ -29,7 +37,7 @@ func TestTransferSuite(t *testing.T) {
 
 func (suite *TransferControllerTestSuite) SetupSuite() {
 	suite.ctx = context.Background()
-	tokenMaker := utils.NewPasetoMaker("")
+	tokenMaker := newTestTokenMaker()
 	validatorObj := validator.New(validator.WithRequiredStructEnabled())
 
 	userService := services.NewUserService(testStore, tokenMaker)
@@ -38,23 +46,34 @@ func (suite *TransferControllerTestSuite) SetupSuite() {
 	accountService := services.NewAccountService(testStore)
 	accountController := NewAccountController(accountService, validatorObj)
 
-	transferService := services.NewTransferService(testStore)
+	transferService := services.NewTransferService(testStore, utils.NewStaticExchangeRateProvider(map[string]utils.ExchangeRate{
+		"EUR/USD": 125_000_000,
+	}), testApprovalThreshold)
 	transferController := NewTransferController(transferService, validatorObj)
 
-	router := http.NewServeMux()
+	router := utils.NewRouteRegistry()
 
-	router.HandleFunc("POST /users/register", userController.HandleRegisterUser)
-	router.HandleFunc("POST /users/login", userController.HandleLoginUser)
+	router.HandleFunc("POST /users/register", utils.PublicRoute(), userController.HandleRegisterUser)
+	router.HandleFunc("POST /users/login", utils.PublicRoute(), userController.HandleLoginUser)
 
-	router.HandleFunc("POST /accounts", accountController.HandleCreateAccount)
-	router.HandleFunc("GET /accounts/{id}", accountController.HandleGetAccount)
-	router.HandleFunc("GET /accounts", accountController.HandleListAccounts)
+	router.HandleFunc("POST /accounts", utils.AllowRoles(utils.CustomerRole), accountController.HandleCreateAccount)
+	router.HandleFunc("GET /accounts/{id}", utils.AllowRoles(utils.AllRoles...), accountController.HandleGetAccount)
+	router.HandleFunc("GET /accounts", utils.AllowRoles(utils.BankerRole, utils.AdminRole), accountController.HandleListAccounts)
+	router.HandleFunc("PUT /accounts/{id}/overdraft-limit", utils.AllowRoles(utils.BankerRole, utils.AdminRole), accountController.HandleSetOverdraftLimit)
+	router.HandleFunc("GET /accounts/{id}/transfers", utils.AllowRoles(utils.AllRoles...), transferController.HandleListTransfers)
+	router.HandleFunc("GET /accounts/{id}/entries", utils.AllowRoles(utils.AllRoles...), accountController.HandleListEntries)
+	router.HandleFunc("GET /accounts/{id}/balance", utils.AllowRoles(utils.AllRoles...), accountController.HandleGetAccountBalance)
 
-	router.HandleFunc("POST /transfers", transferController.HandleCreateTransfer)
+	router.HandleFunc("POST /transfers", utils.AllowRoles(utils.CustomerRole), transferController.HandleCreateTransfer)
+	router.HandleFunc("GET /transfers/{id}", utils.AllowRoles(utils.AllRoles...), transferController.HandleGetTransfer)
+	router.HandleFunc("POST /transfers/{id}/reversals", utils.AllowRoles(utils.BankerRole, utils.AdminRole), transferController.HandleReverseTransfer)
 
-	routerWithMiddleware := middlewares.AuthMiddleware(tokenMaker, router)
+	router.HandleFunc("GET /transfer-approvals", utils.AllowRoles(utils.BankerRole, utils.AdminRole), transferController.HandleListTransferApprovals)
+	router.HandleFunc("GET /transfer-approvals/{id}", utils.AllowRoles(utils.AllRoles...), transferController.HandleGetTransferApproval)
+	router.HandleFunc("POST /transfer-approvals/{id}/approve", utils.AllowRoles(utils.BankerRole), transferController.HandleApproveTransfer)
+	router.HandleFunc("POST /transfer-approvals/{id}/reject", utils.AllowRoles(utils.BankerRole), transferController.HandleRejectTransfer)
 
-	utils.SetProtectedRoutes()
+	routerWithMiddleware := middlewares.AuthMiddleware(tokenMaker, userService, router)
 
 	suite.router = routerWithMiddleware
 }
@@ -64,6 +83,18 @@ func (suite *TransferControllerTestSuite) AfterTest(suiteName string, testName s
 	_, err := testStore.ClearEntriesTable()
 	require.NoError(suite.T(), err)
 
+	_, err = testStore.ClearTransferApprovalsTable()
+	require.NoError(suite.T(), err)
+
+	_, err = testStore.ClearHoldsTable()
+	require.NoError(suite.T(), err)
+
+	_, err = testStore.ClearPostingsTable()
+	require.NoError(suite.T(), err)
+
+	_, err = testStore.ClearJournalsTable()
+	require.NoError(suite.T(), err)
+
 	_, err = testStore.ClearTransfersTable()
 	require.NoError(suite.T(), err)
 
@@ -73,6 +104,9 @@ func (suite *TransferControllerTestSuite) AfterTest(suiteName string, testName s
 	_, err = testStore.ClearSessionsTable()
 	require.NoError(suite.T(), err)
 
+	_, err = testStore.ClearIdempotencyKeysTable()
+	require.NoError(suite.T(), err)
+
 	_, err = testStore.ClearUsersTable()
 	require.NoError(suite.T(), err)
 }
@@ -132,6 +166,269 @@ func (suite *TransferControllerTestSuite) TestCreateTransferSuccess() {
 	require.Equal(suite.T(), http.StatusCreated, recorder.Result().StatusCode)
 }
 
+func (suite *TransferControllerTestSuite) TestCreateTransferInsufficientFunds() {
+	registerUserParam1 := &dto.RegisterUserDto{
+		Email:     "Max@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Max",
+		LastName:  "Mustermann",
+	}
+
+	accessToken1 := registerUserAndLogin(registerUserParam1, suite.router, suite.T())
+	account1 := createAccount(accessToken1, "EUR", suite.router, suite.T())
+
+	registerUserParam2 := &dto.RegisterUserDto{
+		Email:     "Tom@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Tom",
+		LastName:  "Mustermann",
+	}
+
+	accessToken2 := registerUserAndLogin(registerUserParam2, suite.router, suite.T())
+	account2 := createAccount(accessToken2, "EUR", suite.router, suite.T())
+
+	// new accounts have a balance of 0 and no overdraft limit
+	transferParam := &dto.CreateTransferDto{
+		FromAccountId: account1.ID,
+		ToAccountId:   account2.ID,
+		Amount:        100,
+	}
+
+	var body bytes.Buffer
+	err := json.NewEncoder(&body).Encode(transferParam)
+	require.NoError(suite.T(), err)
+
+	request := httptest.NewRequest("POST", "/transfers", &body)
+	request.AddCookie(accessToken1)
+	recorder := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+
+	require.Equal(suite.T(), http.StatusUnprocessableEntity, recorder.Result().StatusCode)
+
+	unchangedAccount1, err := testStore.GetAccount(suite.ctx, account1.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), int64(0), unchangedAccount1.Balance)
+}
+
+func (suite *TransferControllerTestSuite) TestCreateTransferConvertsCurrency() {
+	registerUserParam1 := &dto.RegisterUserDto{
+		Email:     "Max@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Max",
+		LastName:  "Mustermann",
+	}
+
+	accessToken1 := registerUserAndLogin(registerUserParam1, suite.router, suite.T())
+	account1 := createAccount(accessToken1, "EUR", suite.router, suite.T())
+
+	_, err := testStore.SetAccountBalance(suite.ctx, account1.ID, 100)
+	require.NoError(suite.T(), err)
+
+	registerUserParam2 := &dto.RegisterUserDto{
+		Email:     "Tom@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Tom",
+		LastName:  "Mustermann",
+	}
+
+	accessToken2 := registerUserAndLogin(registerUserParam2, suite.router, suite.T())
+	account2 := createAccount(accessToken2, "USD", suite.router, suite.T())
+
+	transferParam := &dto.CreateTransferDto{
+		FromAccountId: account1.ID,
+		ToAccountId:   account2.ID,
+		Amount:        80,
+	}
+
+	var body bytes.Buffer
+	err = json.NewEncoder(&body).Encode(transferParam)
+	require.NoError(suite.T(), err)
+
+	request := httptest.NewRequest("POST", "/transfers", &body)
+	request.AddCookie(accessToken1)
+	recorder := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusCreated, recorder.Result().StatusCode)
+
+	var result db.TransferTxResult
+	err = json.NewDecoder(recorder.Result().Body).Decode(&result)
+	require.NoError(suite.T(), err)
+
+	// the test suite converts EUR to USD with a rate of 1.25
+	require.Equal(suite.T(), int64(80), result.Transfer.Amount)
+	require.Equal(suite.T(), int64(100), result.Transfer.ToAmount)
+	require.Equal(suite.T(), "1.25", result.Transfer.ExchangeRate.String())
+	require.Equal(suite.T(), int64(20), result.FromAccount.Balance)
+	require.Equal(suite.T(), int64(100), result.ToAccount.Balance)
+}
+
+func (suite *TransferControllerTestSuite) TestCreateTransferIdempotencyKeyReplay() {
+	registerUserParam1 := &dto.RegisterUserDto{
+		Email:     "Max@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Max",
+		LastName:  "Mustermann",
+	}
+
+	accessToken1 := registerUserAndLogin(registerUserParam1, suite.router, suite.T())
+	account1 := createAccount(accessToken1, "EUR", suite.router, suite.T())
+
+	_, err := testStore.SetAccountBalance(suite.ctx, account1.ID, 100)
+	require.NoError(suite.T(), err)
+
+	registerUserParam2 := &dto.RegisterUserDto{
+		Email:     "Tom@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Tom",
+		LastName:  "Mustermann",
+	}
+
+	accessToken2 := registerUserAndLogin(registerUserParam2, suite.router, suite.T())
+	account2 := createAccount(accessToken2, "EUR", suite.router, suite.T())
+
+	transferParam := &dto.CreateTransferDto{
+		FromAccountId: account1.ID,
+		ToAccountId:   account2.ID,
+		Amount:        30,
+	}
+
+	// send the same request twice, the second one must return the result of the first one
+	var transferIds []int64
+	for i := 0; i < 2; i++ {
+		var body bytes.Buffer
+		err = json.NewEncoder(&body).Encode(transferParam)
+		require.NoError(suite.T(), err)
+
+		request := httptest.NewRequest("POST", "/transfers", &body)
+		request.Header.Set("Idempotency-Key", "transfer-1")
+		request.AddCookie(accessToken1)
+		recorder := httptest.NewRecorder()
+
+		suite.router.ServeHTTP(recorder, request)
+		require.Equal(suite.T(), http.StatusCreated, recorder.Result().StatusCode)
+
+		var result db.TransferTxResult
+		err = json.NewDecoder(recorder.Result().Body).Decode(&result)
+		require.NoError(suite.T(), err)
+
+		transferIds = append(transferIds, result.Transfer.ID)
+	}
+
+	require.Equal(suite.T(), transferIds[0], transferIds[1])
+
+	// the money must only be transferred once
+	updatedAccount1, err := testStore.GetAccount(suite.ctx, account1.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), int64(70), updatedAccount1.Balance)
+
+	// reusing the key for a different request is rejected
+	transferParam.Amount = 40
+
+	var body bytes.Buffer
+	err = json.NewEncoder(&body).Encode(transferParam)
+	require.NoError(suite.T(), err)
+
+	request := httptest.NewRequest("POST", "/transfers", &body)
+	request.Header.Set("Idempotency-Key", "transfer-1")
+	request.AddCookie(accessToken1)
+	recorder := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusUnprocessableEntity, recorder.Result().StatusCode)
+
+	unchangedAccount1, err := testStore.GetAccount(suite.ctx, account1.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), int64(70), unchangedAccount1.Balance)
+}
+
+func (suite *TransferControllerTestSuite) TestCreateTransferIdempotencyKeyLease() {
+	registerUserParam1 := &dto.RegisterUserDto{
+		Email:     "Max@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Max",
+		LastName:  "Mustermann",
+	}
+
+	accessToken1 := registerUserAndLogin(registerUserParam1, suite.router, suite.T())
+	account1 := createAccount(accessToken1, "EUR", suite.router, suite.T())
+
+	_, err := testStore.SetAccountBalance(suite.ctx, account1.ID, 100)
+	require.NoError(suite.T(), err)
+
+	registerUserParam2 := &dto.RegisterUserDto{
+		Email:     "Tom@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Tom",
+		LastName:  "Mustermann",
+	}
+
+	accessToken2 := registerUserAndLogin(registerUserParam2, suite.router, suite.T())
+	account2 := createAccount(accessToken2, "EUR", suite.router, suite.T())
+
+	// reservations left by requests that did not finish, one of them is still within its lease
+	now := time.Now()
+	leases := map[string]time.Time{
+		"running": now.Add(time.Minute),
+		"crashed": now.Add(-time.Second),
+	}
+
+	for key, lockedUntil := range leases {
+		_, err = testStore.CreateIdempotencyKey(suite.ctx, &db.CreateIdempotencyKeyParams{
+			Email:          registerUserParam1.Email,
+			IdempotencyKey: key,
+			RequestHash:    "unknown",
+			ExpiresAt:      now.Add(time.Hour),
+			LockedUntil:    &lockedUntil,
+		})
+		require.NoError(suite.T(), err)
+	}
+
+	transferParam := &dto.CreateTransferDto{
+		FromAccountId: account1.ID,
+		ToAccountId:   account2.ID,
+		Amount:        30,
+	}
+
+	sendTransfer := func(key string) int {
+		var body bytes.Buffer
+		err := json.NewEncoder(&body).Encode(transferParam)
+		require.NoError(suite.T(), err)
+
+		request := httptest.NewRequest("POST", "/transfers", &body)
+		request.Header.Set("Idempotency-Key", key)
+		request.AddCookie(accessToken1)
+		recorder := httptest.NewRecorder()
+
+		suite.router.ServeHTTP(recorder, request)
+		return recorder.Result().StatusCode
+	}
+
+	// the reservation within its lease is not taken over
+	require.NotEqual(suite.T(), http.StatusCreated, sendTransfer("running"))
+
+	unchangedAccount1, err := testStore.GetAccount(suite.ctx, account1.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), int64(100), unchangedAccount1.Balance)
+
+	// the reservation after its lease is taken over and the request is executed once
+	require.Equal(suite.T(), http.StatusCreated, sendTransfer("crashed"))
+	require.Equal(suite.T(), http.StatusCreated, sendTransfer("crashed"))
+
+	updatedAccount1, err := testStore.GetAccount(suite.ctx, account1.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), int64(70), updatedAccount1.Balance)
+
+	storedKey, err := testStore.GetIdempotencyKey(suite.ctx, &db.GetIdempotencyKeyParams{
+		Email:          registerUserParam1.Email,
+		IdempotencyKey: "crashed",
+	})
+	require.NoError(suite.T(), err)
+	require.NotNil(suite.T(), storedKey.ResponseBody)
+	require.Nil(suite.T(), storedKey.LockedUntil)
+}
+
 func (suite *TransferControllerTestSuite) TestCreateTransferFailAccountAndOwnerNotMatch() {
 	// prepare first user and its account
 	registerUserParam1 := &dto.RegisterUserDto{
@@ -186,3 +483,545 @@ func (suite *TransferControllerTestSuite) TestCreateTransferFailAccountAndOwnerN
 
 	require.Equal(suite.T(), http.StatusUnauthorized, recorder.Result().StatusCode)
 }
+
+func (suite *TransferControllerTestSuite) TestGetTransfer() {
+	registerUserParam1 := &dto.RegisterUserDto{
+		Email:     "Max@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Max",
+		LastName:  "Mustermann",
+	}
+
+	accessToken1 := registerUserAndLogin(registerUserParam1, suite.router, suite.T())
+	account1 := createAccount(accessToken1, "EUR", suite.router, suite.T())
+
+	_, err := testStore.SetAccountBalance(suite.ctx, account1.ID, 100)
+	require.NoError(suite.T(), err)
+
+	registerUserParam2 := &dto.RegisterUserDto{
+		Email:     "Tom@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Tom",
+		LastName:  "Mustermann",
+	}
+
+	accessToken2 := registerUserAndLogin(registerUserParam2, suite.router, suite.T())
+	account2 := createAccount(accessToken2, "EUR", suite.router, suite.T())
+
+	registerUserParam3 := &dto.RegisterUserDto{
+		Email:     "Tim@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Tim",
+		LastName:  "Mustermann",
+	}
+
+	accessToken3 := registerUserAndLogin(registerUserParam3, suite.router, suite.T())
+
+	transfer := createTransfer(accessToken1, account1.ID, account2.ID, 30, suite.router, suite.T())
+
+	// sender and receiver can see the transfer
+	for _, accessToken := range []*http.Cookie{accessToken1, accessToken2} {
+		request := httptest.NewRequest("GET", fmt.Sprintf("/transfers/%d", transfer.ID), nil)
+		request.AddCookie(accessToken)
+		recorder := httptest.NewRecorder()
+
+		suite.router.ServeHTTP(recorder, request)
+		require.Equal(suite.T(), http.StatusOK, recorder.Result().StatusCode)
+
+		var result db.Transfer
+		err = json.NewDecoder(recorder.Result().Body).Decode(&result)
+		require.NoError(suite.T(), err)
+		require.Equal(suite.T(), transfer.ID, result.ID)
+		require.Equal(suite.T(), int64(30), result.Amount)
+	}
+
+	// other customers cannot
+	request := httptest.NewRequest("GET", fmt.Sprintf("/transfers/%d", transfer.ID), nil)
+	request.AddCookie(accessToken3)
+	recorder := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusUnauthorized, recorder.Result().StatusCode)
+
+	request = httptest.NewRequest("GET", fmt.Sprintf("/transfers/%d", transfer.ID+1), nil)
+	request.AddCookie(accessToken1)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusNotFound, recorder.Result().StatusCode)
+}
+
+func (suite *TransferControllerTestSuite) TestListTransfersAndEntries() {
+	registerUserParam1 := &dto.RegisterUserDto{
+		Email:     "Max@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Max",
+		LastName:  "Mustermann",
+	}
+
+	accessToken1 := registerUserAndLogin(registerUserParam1, suite.router, suite.T())
+	account1 := createAccount(accessToken1, "EUR", suite.router, suite.T())
+
+	_, err := testStore.SetAccountBalance(suite.ctx, account1.ID, 100)
+	require.NoError(suite.T(), err)
+
+	registerUserParam2 := &dto.RegisterUserDto{
+		Email:     "Tom@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Tom",
+		LastName:  "Mustermann",
+	}
+
+	accessToken2 := registerUserAndLogin(registerUserParam2, suite.router, suite.T())
+	account2 := createAccount(accessToken2, "EUR", suite.router, suite.T())
+
+	createTransfer(accessToken1, account1.ID, account2.ID, 30, suite.router, suite.T())
+	incomingTransfer := createTransfer(accessToken2, account2.ID, account1.ID, 10, suite.router, suite.T())
+
+	testCases := []struct {
+		name          string
+		url           string
+		accessToken   *http.Cookie
+		expectedCode  int
+		expectedCount int
+	}{
+		{"AllTransfers", fmt.Sprintf("/accounts/%d/transfers?limit=10", account1.ID), accessToken1, http.StatusOK, 2},
+		{"OutgoingTransfers", fmt.Sprintf("/accounts/%d/transfers?limit=10&direction=outgoing", account1.ID), accessToken1, http.StatusOK, 1},
+		{"FutureTransfers", fmt.Sprintf("/accounts/%d/transfers?limit=10&start_time=2999-01-01T00:00:00Z", account1.ID), accessToken1, http.StatusOK, 0},
+		{"TransfersOfOtherAccount", fmt.Sprintf("/accounts/%d/transfers?limit=10", account1.ID), accessToken2, http.StatusUnauthorized, 0},
+		{"TransfersWithoutLimit", fmt.Sprintf("/accounts/%d/transfers", account1.ID), accessToken1, http.StatusOK, 2},
+		{"TransfersLimitTooLarge", fmt.Sprintf("/accounts/%d/transfers?limit=1001", account1.ID), accessToken1, http.StatusBadRequest, 0},
+		{"TransfersInvalidDirection", fmt.Sprintf("/accounts/%d/transfers?limit=10&direction=sideways", account1.ID), accessToken1, http.StatusBadRequest, 0},
+		{"TransfersInvalidTimeRange", fmt.Sprintf("/accounts/%d/transfers?limit=10&start_time=2024-02-01T00:00:00Z&end_time=2024-01-01T00:00:00Z", account1.ID), accessToken1, http.StatusBadRequest, 0},
+		{"AllEntries", fmt.Sprintf("/accounts/%d/entries?limit=10", account1.ID), accessToken1, http.StatusOK, 2},
+		{"IncomingEntries", fmt.Sprintf("/accounts/%d/entries?limit=10&direction=incoming", account1.ID), accessToken1, http.StatusOK, 1},
+		{"EntriesOfOtherAccount", fmt.Sprintf("/accounts/%d/entries?limit=10", account1.ID), accessToken2, http.StatusUnauthorized, 0},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			request := httptest.NewRequest("GET", testCase.url, nil)
+			request.AddCookie(testCase.accessToken)
+			recorder := httptest.NewRecorder()
+
+			suite.router.ServeHTTP(recorder, request)
+			require.Equal(suite.T(), testCase.expectedCode, recorder.Result().StatusCode)
+
+			if testCase.expectedCode != http.StatusOK {
+				return
+			}
+
+			var result dto.PageDto[json.RawMessage]
+			err := json.NewDecoder(recorder.Result().Body).Decode(&result)
+			require.NoError(suite.T(), err)
+			require.Len(suite.T(), result.Items, testCase.expectedCount)
+			require.False(suite.T(), result.HasMore)
+		})
+	}
+
+	// entries reference the transfer that produced them
+	request := httptest.NewRequest("GET", fmt.Sprintf("/accounts/%d/entries?limit=10&direction=incoming", account1.ID), nil)
+	request.AddCookie(accessToken1)
+	recorder := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusOK, recorder.Result().StatusCode)
+
+	var entries dto.PageDto[*db.Entry]
+	err = json.NewDecoder(recorder.Result().Body).Decode(&entries)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), entries.Items, 1)
+	require.Equal(suite.T(), &incomingTransfer.ID, entries.Items[0].TransferID)
+	require.NotNil(suite.T(), entries.Items[0].JournalID)
+	require.Equal(suite.T(), db.EntryTransfer, entries.Items[0].Category)
+	require.Equal(suite.T(), fmt.Sprintf("transfer from account %d", account2.ID), entries.Items[0].Description)
+}
+
+func (suite *TransferControllerTestSuite) TestGetAccountBalance() {
+	registerUserParam1 := &dto.RegisterUserDto{
+		Email:     "Max@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Max",
+		LastName:  "Mustermann",
+	}
+
+	accessToken1 := registerUserAndLogin(registerUserParam1, suite.router, suite.T())
+	account1 := createAccount(accessToken1, "EUR", suite.router, suite.T())
+
+	_, err := testStore.SetAccountBalance(suite.ctx, account1.ID, 100)
+	require.NoError(suite.T(), err)
+
+	registerUserParam2 := &dto.RegisterUserDto{
+		Email:     "Tom@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Tom",
+		LastName:  "Mustermann",
+	}
+
+	accessToken2 := registerUserAndLogin(registerUserParam2, suite.router, suite.T())
+	account2 := createAccount(accessToken2, "EUR", suite.router, suite.T())
+
+	createTransfer(accessToken1, account1.ID, account2.ID, 30, suite.router, suite.T())
+	createTransfer(accessToken2, account2.ID, account1.ID, 10, suite.router, suite.T())
+
+	now := url.QueryEscape(time.Now().UTC().Format(time.RFC3339Nano))
+
+	testCases := []struct {
+		name            string
+		url             string
+		accessToken     *http.Cookie
+		expectedCode    int
+		expectedBalance int64
+	}{
+		{"CurrentBalance", fmt.Sprintf("/accounts/%d/balance?at=%s", account2.ID, now), accessToken2, http.StatusOK, 20},
+		{"BeforeFirstEntry", fmt.Sprintf("/accounts/%d/balance?at=2000-01-01T00:00:00Z", account2.ID), accessToken2, http.StatusOK, 0},
+		{"FutureTime", fmt.Sprintf("/accounts/%d/balance?at=2999-01-01T00:00:00Z", account2.ID), accessToken2, http.StatusBadRequest, 0},
+		{"WithoutTime", fmt.Sprintf("/accounts/%d/balance", account2.ID), accessToken2, http.StatusBadRequest, 0},
+		{"BalanceOfOtherAccount", fmt.Sprintf("/accounts/%d/balance?at=%s", account2.ID, now), accessToken1, http.StatusUnauthorized, 0},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.name, func() {
+			request := httptest.NewRequest("GET", testCase.url, nil)
+			request.AddCookie(testCase.accessToken)
+			recorder := httptest.NewRecorder()
+
+			suite.router.ServeHTTP(recorder, request)
+			require.Equal(suite.T(), testCase.expectedCode, recorder.Result().StatusCode)
+
+			if testCase.expectedCode != http.StatusOK {
+				return
+			}
+
+			var result dto.AccountBalanceDto
+			err := json.NewDecoder(recorder.Result().Body).Decode(&result)
+			require.NoError(suite.T(), err)
+			require.Equal(suite.T(), account2.ID, result.AccountId)
+			require.Equal(suite.T(), "EUR", result.Currency)
+			require.Equal(suite.T(), testCase.expectedBalance, result.Balance)
+		})
+	}
+}
+
+func (suite *TransferControllerTestSuite) TestReverseTransfer() {
+	registerUserParam1 := &dto.RegisterUserDto{
+		Email:     "Max@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Max",
+		LastName:  "Mustermann",
+	}
+
+	accessToken1 := registerUserAndLogin(registerUserParam1, suite.router, suite.T())
+	account1 := createAccount(accessToken1, "EUR", suite.router, suite.T())
+
+	_, err := testStore.SetAccountBalance(suite.ctx, account1.ID, 100)
+	require.NoError(suite.T(), err)
+
+	registerUserParam2 := &dto.RegisterUserDto{
+		Email:     "Tom@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Tom",
+		LastName:  "Mustermann",
+	}
+
+	accessToken2 := registerUserAndLogin(registerUserParam2, suite.router, suite.T())
+	account2 := createAccount(accessToken2, "EUR", suite.router, suite.T())
+
+	bankerAccessToken := registerBankerAndLogin("Tim@Mustermann.de", suite.router, suite.T())
+
+	transfer := createTransfer(accessToken1, account1.ID, account2.ID, 100, suite.router, suite.T())
+	path := fmt.Sprintf("/transfers/%d/reversals", transfer.ID)
+
+	// customers cannot reverse transfers
+	request := httptest.NewRequest("POST", path, nil)
+	request.AddCookie(accessToken1)
+	recorder := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusUnauthorized, recorder.Result().StatusCode)
+
+	// partial refund
+	request = httptest.NewRequest("POST", path, bytes.NewBufferString(`{"amount": 30}`))
+	request.AddCookie(bankerAccessToken)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusCreated, recorder.Result().StatusCode)
+
+	var refund db.TransferTxResult
+	err = json.NewDecoder(recorder.Result().Body).Decode(&refund)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), account2.ID, refund.Transfer.FromAccountID)
+	require.Equal(suite.T(), account1.ID, refund.Transfer.ToAccountID)
+	require.Equal(suite.T(), int64(30), refund.Transfer.Amount)
+	require.NotNil(suite.T(), refund.Transfer.ReversalOf)
+	require.Equal(suite.T(), transfer.ID, *refund.Transfer.ReversalOf)
+	require.Equal(suite.T(), int64(30), refund.ToAccount.Balance)
+	require.Equal(suite.T(), int64(70), refund.FromAccount.Balance)
+
+	// more than the rest of the transfer cannot be refunded
+	request = httptest.NewRequest("POST", path, bytes.NewBufferString(`{"amount": 71}`))
+	request.AddCookie(bankerAccessToken)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusUnprocessableEntity, recorder.Result().StatusCode)
+
+	// without an amount the rest is reversed
+	request = httptest.NewRequest("POST", path, nil)
+	request.AddCookie(bankerAccessToken)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusCreated, recorder.Result().StatusCode)
+
+	var reversal db.TransferTxResult
+	err = json.NewDecoder(recorder.Result().Body).Decode(&reversal)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), int64(70), reversal.Transfer.Amount)
+	require.Equal(suite.T(), int64(100), reversal.ToAccount.Balance)
+	require.Equal(suite.T(), int64(0), reversal.FromAccount.Balance)
+
+	// the transfer cannot be reversed twice
+	request = httptest.NewRequest("POST", path, nil)
+	request.AddCookie(bankerAccessToken)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusConflict, recorder.Result().StatusCode)
+
+	// reversals cannot be reversed
+	request = httptest.NewRequest("POST", fmt.Sprintf("/transfers/%d/reversals", reversal.Transfer.ID), nil)
+	request.AddCookie(bankerAccessToken)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusUnprocessableEntity, recorder.Result().StatusCode)
+
+	request = httptest.NewRequest("POST", fmt.Sprintf("/transfers/%d/reversals", reversal.Transfer.ID+1), nil)
+	request.AddCookie(bankerAccessToken)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusNotFound, recorder.Result().StatusCode)
+}
+
+func (suite *TransferControllerTestSuite) TestTransferApproval() {
+	registerUserParam1 := &dto.RegisterUserDto{
+		Email:     "Max@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Max",
+		LastName:  "Mustermann",
+	}
+
+	accessToken1 := registerUserAndLogin(registerUserParam1, suite.router, suite.T())
+	account1 := createAccount(accessToken1, "EUR", suite.router, suite.T())
+
+	_, err := testStore.SetAccountBalance(suite.ctx, account1.ID, 5000)
+	require.NoError(suite.T(), err)
+
+	registerUserParam2 := &dto.RegisterUserDto{
+		Email:     "Tom@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Tom",
+		LastName:  "Mustermann",
+	}
+
+	accessToken2 := registerUserAndLogin(registerUserParam2, suite.router, suite.T())
+	account2 := createAccount(accessToken2, "EUR", suite.router, suite.T())
+
+	bankerAccessToken := registerBankerAndLogin("Tim@Mustermann.de", suite.router, suite.T())
+
+	// transfers above the threshold wait for an approval and hold the amount
+	approval := createPendingTransfer(accessToken1, account1.ID, account2.ID, testApprovalThreshold+1000, suite.router, suite.T())
+	require.Equal(suite.T(), db.TransferApprovalPending, approval.Status)
+	require.Equal(suite.T(), registerUserParam1.Email, approval.Initiator)
+	require.NotNil(suite.T(), approval.HoldID)
+
+	heldAccount, err := testStore.GetAccount(suite.ctx, account1.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), int64(5000), heldAccount.Balance)
+	require.Equal(suite.T(), int64(2000), heldAccount.HeldAmount)
+
+	// held money is not available for other transfers
+	transferParam := &dto.CreateTransferDto{
+		FromAccountId: account1.ID,
+		ToAccountId:   account2.ID,
+		Amount:        3001,
+	}
+
+	var body bytes.Buffer
+	err = json.NewEncoder(&body).Encode(transferParam)
+	require.NoError(suite.T(), err)
+
+	request := httptest.NewRequest("POST", "/transfers", &body)
+	request.AddCookie(accessToken1)
+	recorder := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusUnprocessableEntity, recorder.Result().StatusCode)
+
+	// only bankers see the approval queue, the initiator can see its approval
+	request = httptest.NewRequest("GET", "/transfer-approvals?limit=10", nil)
+	request.AddCookie(accessToken1)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusUnauthorized, recorder.Result().StatusCode)
+
+	request = httptest.NewRequest("GET", "/transfer-approvals?limit=10", nil)
+	request.AddCookie(bankerAccessToken)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusOK, recorder.Result().StatusCode)
+
+	var queue dto.PageDto[*db.TransferApproval]
+	err = json.NewDecoder(recorder.Result().Body).Decode(&queue)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), queue.Items, 1)
+	require.Equal(suite.T(), approval.ID, queue.Items[0].ID)
+
+	for accessToken, expectedCode := range map[*http.Cookie]int{accessToken1: http.StatusOK, accessToken2: http.StatusUnauthorized} {
+		request = httptest.NewRequest("GET", fmt.Sprintf("/transfer-approvals/%d", approval.ID), nil)
+		request.AddCookie(accessToken)
+		recorder = httptest.NewRecorder()
+
+		suite.router.ServeHTTP(recorder, request)
+		require.Equal(suite.T(), expectedCode, recorder.Result().StatusCode)
+	}
+
+	// customers cannot approve transfers
+	request = httptest.NewRequest("POST", fmt.Sprintf("/transfer-approvals/%d/approve", approval.ID), nil)
+	request.AddCookie(accessToken2)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusUnauthorized, recorder.Result().StatusCode)
+
+	// the approval executes the transfer and releases the hold
+	request = httptest.NewRequest("POST", fmt.Sprintf("/transfer-approvals/%d/approve", approval.ID), nil)
+	request.AddCookie(bankerAccessToken)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusOK, recorder.Result().StatusCode)
+
+	var approved db.ApproveTransferTxResult
+	err = json.NewDecoder(recorder.Result().Body).Decode(&approved)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), db.TransferApprovalApproved, approved.Approval.Status)
+	require.Equal(suite.T(), "Tim@Mustermann.de", *approved.Approval.Reviewer)
+	require.Equal(suite.T(), approved.Transfer.ID, *approved.Approval.TransferID)
+	require.Equal(suite.T(), int64(3000), approved.FromAccount.Balance)
+	require.Equal(suite.T(), int64(0), approved.FromAccount.HeldAmount)
+	require.Equal(suite.T(), int64(2000), approved.ToAccount.Balance)
+
+	// a decided transfer cannot be approved or rejected again
+	request = httptest.NewRequest("POST", fmt.Sprintf("/transfer-approvals/%d/reject", approval.ID), nil)
+	request.AddCookie(bankerAccessToken)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusConflict, recorder.Result().StatusCode)
+
+	// a rejection releases the hold without a transfer
+	approval = createPendingTransfer(accessToken1, account1.ID, account2.ID, testApprovalThreshold+500, suite.router, suite.T())
+
+	request = httptest.NewRequest("POST", fmt.Sprintf("/transfer-approvals/%d/reject", approval.ID), nil)
+	request.AddCookie(bankerAccessToken)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusOK, recorder.Result().StatusCode)
+
+	var rejected db.TransferApproval
+	err = json.NewDecoder(recorder.Result().Body).Decode(&rejected)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), db.TransferApprovalRejected, rejected.Status)
+	require.Nil(suite.T(), rejected.TransferID)
+
+	releasedAccount, err := testStore.GetAccount(suite.ctx, account1.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), int64(3000), releasedAccount.Balance)
+	require.Equal(suite.T(), int64(0), releasedAccount.HeldAmount)
+}
+
+// helper function for tests that need transfers
+func createTransfer(accessToken *http.Cookie, fromAccountId int64, toAccountId int64, amount int64, router http.Handler, t *testing.T) *db.Transfer {
+	transferParam := &dto.CreateTransferDto{
+		FromAccountId: fromAccountId,
+		ToAccountId:   toAccountId,
+		Amount:        amount,
+	}
+
+	var body bytes.Buffer
+	err := json.NewEncoder(&body).Encode(transferParam)
+	require.NoError(t, err)
+
+	request := httptest.NewRequest("POST", "/transfers", &body)
+	request.AddCookie(accessToken)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusCreated, recorder.Result().StatusCode)
+
+	var result db.TransferTxResult
+	err = json.NewDecoder(recorder.Result().Body).Decode(&result)
+	require.NoError(t, err)
+
+	return result.Transfer
+}
+
+// helper function for tests that need transfers above the approval threshold
+func createPendingTransfer(accessToken *http.Cookie, fromAccountId int64, toAccountId int64, amount int64, router http.Handler, t *testing.T) *db.TransferApproval {
+	transferParam := &dto.CreateTransferDto{
+		FromAccountId: fromAccountId,
+		ToAccountId:   toAccountId,
+		Amount:        amount,
+	}
+
+	var body bytes.Buffer
+	err := json.NewEncoder(&body).Encode(transferParam)
+	require.NoError(t, err)
+
+	request := httptest.NewRequest("POST", "/transfers", &body)
+	request.AddCookie(accessToken)
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusAccepted, recorder.Result().StatusCode)
+
+	var result services.CreateTransferResult
+	err = json.NewDecoder(recorder.Result().Body).Decode(&result)
+	require.NoError(t, err)
+	require.Nil(t, result.TransferTxResult)
+	require.NotNil(t, result.Approval)
+
+	return result.Approval
+}
+
+// helper function for tests that need a banker, bankers cannot register themselves
+func registerBankerAndLogin(email string, router http.Handler, t *testing.T) *http.Cookie {
+	return registerStaffAndLogin(email, utils.BankerRole, router, t)
+}
+
+// registerStaffAndLogin registers a user with the given role directly in the store, the api only registers customers
+func registerStaffAndLogin(email string, role string, router http.Handler, t *testing.T) *http.Cookie {
+	hashedPasswordBytes, err := bcrypt.GenerateFromPassword([]byte("Test1234"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+
+	staff, err := testStore.RegisterUser(context.Background(), &db.RegisterUserParams{
+		Email:          email,
+		HashedPassword: string(hashedPasswordBytes),
+		FirstName:      "Bank",
+		LastName:       "Staff",
+		UserRole:       role,
+	})
+	require.NoError(t, err)
+
+	return loginUser(&dto.LoginUserDto{
+		Email:    staff.Email,
+		Password: "Test1234",
+	}, router, t)
+}
@@ -3,11 +3,12 @@ package rest
 import (
 	"encoding/json"
 	"kara-bank/dto"
+	"kara-bank/middlewares"
 	"kara-bank/services"
 	"net/http"
-	"time"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
 )
 
 type UserController struct {
@@ -76,7 +77,7 @@ func (u *UserController) HandleLoginUser(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	token, respErr := u.userService.LoginUser(r.Context(), &requestBody)
+	result, respErr := u.userService.LoginUser(r.Context(), &requestBody)
 
 	if respErr != nil {
 		http.Error(w, respErr.Message, respErr.Status)
@@ -85,11 +86,227 @@ func (u *UserController) HandleLoginUser(w http.ResponseWriter, r *http.Request)
 
 	http.SetCookie(w, &http.Cookie{
 		Name:     "access_token",
-		Value:    token,
+		Value:    result.AccessToken,
 		Secure:   true,
 		HttpOnly: true,
-		Expires:  time.Now().Add(30 * time.Minute),
+		Expires:  result.AccessTokenExpiresAt,
 	})
 
+	http.SetCookie(w, &http.Cookie{
+		Name:     "refresh_token",
+		Value:    result.RefreshToken,
+		Secure:   true,
+		HttpOnly: true,
+		Expires:  result.RefreshTokenExpiresAt,
+	})
+
+	if !requestBody.ReturnTokens {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	responseJson, err := json.Marshal(&result)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseJson)
+}
+
+func (u *UserController) HandleRefreshToken(w http.ResponseWriter, r *http.Request) {
+	var requestBody dto.RefreshTokenDto
+
+	// the refresh token cookie set on login takes precedence over the request body
+	refreshTokenCookie, err := r.Cookie("refresh_token")
+	fromCookie := err == nil
+
+	if fromCookie {
+		requestBody.RefreshToken = refreshTokenCookie.Value
+	} else {
+		err = json.NewDecoder(r.Body).Decode(&requestBody)
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	err = u.validator.Struct(requestBody)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, respErr := u.userService.RefreshAccessToken(r.Context(), requestBody.RefreshToken)
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     "access_token",
+		Value:    result.AccessToken,
+		Secure:   true,
+		HttpOnly: true,
+		Expires:  result.AccessTokenExpiresAt,
+	})
+
+	// clients that sent the refresh token in the body cannot read cookies either
+	if fromCookie {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	responseJson, err := json.Marshal(&result)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
+	w.Write(responseJson)
+}
+
+func (u *UserController) HandleLogoutUser(w http.ResponseWriter, r *http.Request) {
+	sessionId, ok := r.Context().Value(middlewares.ContextSessionIdKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "Could not extract session id from token", http.StatusInternalServerError)
+		return
+	}
+
+	respErr := u.userService.LogoutUser(r.Context(), sessionId)
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	clearTokenCookies(w)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (u *UserController) HandleLogoutUserEverywhere(w http.ResponseWriter, r *http.Request) {
+	email, ok := r.Context().Value(middlewares.ContextUserEmailKey).(string)
+
+	if !ok {
+		http.Error(w, "Could not extract email from token", http.StatusInternalServerError)
+		return
+	}
+
+	respErr := u.userService.LogoutUserEverywhere(r.Context(), email)
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	clearTokenCookies(w)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (u *UserController) HandleRevokeUserSessions(w http.ResponseWriter, r *http.Request) {
+	email := r.PathValue("email")
+
+	err := u.validator.Var(email, "required,email")
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	role, ok := r.Context().Value(middlewares.ContextUserRoleKey).(string)
+
+	if !ok {
+		http.Error(w, "Could not extract role from token", http.StatusInternalServerError)
+		return
+	}
+
+	respErr := u.userService.RevokeUserSessions(r.Context(), email, role)
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (u *UserController) HandleListSessions(w http.ResponseWriter, r *http.Request) {
+	email, ok := r.Context().Value(middlewares.ContextUserEmailKey).(string)
+
+	if !ok {
+		http.Error(w, "Could not extract email from token", http.StatusInternalServerError)
+		return
+	}
+
+	sessionId, ok := r.Context().Value(middlewares.ContextSessionIdKey).(uuid.UUID)
+
+	if !ok {
+		http.Error(w, "Could not extract session id from token", http.StatusInternalServerError)
+		return
+	}
+
+	sessions, respErr := u.userService.ListSessions(r.Context(), email, sessionId)
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	responseJson, err := json.Marshal(&sessions)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseJson)
+}
+
+func (u *UserController) HandleRevokeSession(w http.ResponseWriter, r *http.Request) {
+	sessionId, err := uuid.Parse(r.PathValue("id"))
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	email, ok := r.Context().Value(middlewares.ContextUserEmailKey).(string)
+
+	if !ok {
+		http.Error(w, "Could not extract email from token", http.StatusInternalServerError)
+		return
+	}
+
+	respErr := u.userService.RevokeSession(r.Context(), sessionId, email)
+
+	if respErr != nil {
+		http.Error(w, respErr.Message, respErr.Status)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func clearTokenCookies(w http.ResponseWriter) {
+	for _, name := range []string{"access_token", "refresh_token"} {
+		http.SetCookie(w, &http.Cookie{
+			Name:     name,
+			Value:    "",
+			Secure:   true,
+			HttpOnly: true,
+			MaxAge:   -1,
+		})
+	}
 }
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	db "kara-bank/db/repositories"
 	"kara-bank/dto"
+	"kara-bank/middlewares"
 	"kara-bank/services"
 	"kara-bank/utils"
 	"log"
@@ -15,12 +17,13 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
 )
 
 type UserControllerTestSuite struct {
 	suite.Suite
 	ctx    context.Context
-	router *http.ServeMux
+	router http.Handler
 }
 
 func TestUserControllerSuite(t *testing.T) {
@@ -30,14 +33,24 @@ func TestUserControllerSuite(t *testing.T) {
 func (suite *UserControllerTestSuite) SetupSuite() {
 	suite.ctx = context.Background()
 
-	userService := services.NewUserService(testStore, utils.NewPasetoMaker(""))
+	tokenMaker := newTestTokenMaker()
+
+	userService := services.NewUserService(testStore, tokenMaker)
 	userController := NewUserController(userService, validator.New(validator.WithRequiredStructEnabled()))
 
-	router := http.NewServeMux()
-	router.HandleFunc("POST /users/register", userController.HandleRegisterUser)
-	router.HandleFunc("POST /users/login", userController.HandleLoginUser)
+	router := utils.NewRouteRegistry()
+	router.HandleFunc("POST /users/register", utils.PublicRoute(), userController.HandleRegisterUser)
+	router.HandleFunc("POST /users/login", utils.PublicRoute(), userController.HandleLoginUser)
+	router.HandleFunc("POST /users/token/refresh", utils.PublicRoute(), userController.HandleRefreshToken)
+	router.HandleFunc("POST /users/logout", utils.AllowRoles(utils.AllRoles...), userController.HandleLogoutUser)
+	router.HandleFunc("POST /users/logout/all", utils.AllowRoles(utils.AllRoles...), userController.HandleLogoutUserEverywhere)
+	router.HandleFunc("DELETE /users/{email}/sessions", utils.AllowRoles(utils.BankerRole, utils.AdminRole), userController.HandleRevokeUserSessions)
+	router.HandleFunc("GET /users/me/sessions", utils.AllowRoles(utils.AllRoles...), userController.HandleListSessions)
+	router.HandleFunc("DELETE /users/me/sessions/{id}", utils.AllowRoles(utils.AllRoles...), userController.HandleRevokeSession)
+
+	routerWithMiddleware := middlewares.AuthMiddleware(tokenMaker, userService, router)
 
-	suite.router = router
+	suite.router = routerWithMiddleware
 }
 
 func (suite *UserControllerTestSuite) AfterTest(suiteName string, testName string) {
@@ -269,6 +282,335 @@ func (suite *UserControllerTestSuite) TestLoginSuccess() {
 	require.Equal(suite.T(), 200, recorder.Result().StatusCode)
 }
 
+func (suite *UserControllerTestSuite) TestRefreshTokenSuccess() {
+	registerUserParam := &dto.RegisterUserDto{
+		Email:     "Max@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Max",
+		LastName:  "Mustermann",
+	}
+	accessTokenCookie := registerUserAndLogin(registerUserParam, suite.router, suite.T())
+	require.NotNil(suite.T(), accessTokenCookie)
+
+	// login again to get hold of the refresh token cookie
+	loginRequestParam := &dto.LoginUserDto{
+		Email:    registerUserParam.Email,
+		Password: registerUserParam.Password,
+	}
+
+	loginRequestParamBytes, err := json.Marshal(loginRequestParam)
+	require.NoError(suite.T(), err)
+
+	request := httptest.NewRequest("POST", "/users/login", bytes.NewReader(loginRequestParamBytes))
+	request.RemoteAddr = "test"
+	request.Header.Set("User-Agent", "test")
+	recorder := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), 200, recorder.Result().StatusCode)
+
+	refreshTokenCookie := getCookie(recorder.Result().Cookies(), "refresh_token")
+	require.NotNil(suite.T(), refreshTokenCookie)
+
+	// refresh with cookie
+	request = httptest.NewRequest("POST", "/users/token/refresh", nil)
+	request.AddCookie(refreshTokenCookie)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), 200, recorder.Result().StatusCode)
+	require.NotNil(suite.T(), getCookie(recorder.Result().Cookies(), "access_token"))
+
+	// refresh with request body
+	refreshTokenParamBytes, err := json.Marshal(&dto.RefreshTokenDto{
+		RefreshToken: refreshTokenCookie.Value,
+	})
+	require.NoError(suite.T(), err)
+
+	request = httptest.NewRequest("POST", "/users/token/refresh", bytes.NewReader(refreshTokenParamBytes))
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), 200, recorder.Result().StatusCode)
+	require.NotNil(suite.T(), getCookie(recorder.Result().Cookies(), "access_token"))
+}
+
+func (suite *UserControllerTestSuite) TestRefreshTokenFailInvalidToken() {
+	request := httptest.NewRequest("POST", "/users/token/refresh", nil)
+	request.AddCookie(&http.Cookie{
+		Name:  "refresh_token",
+		Value: "invalid",
+	})
+	recorder := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+
+	require.Equal(suite.T(), 401, recorder.Result().StatusCode)
+}
+
+func (suite *UserControllerTestSuite) TestRefreshTokenFailWithAccessToken() {
+	accessTokenCookie := registerUserAndLogin(&dto.RegisterUserDto{
+		Email:     "Max@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Max",
+		LastName:  "Mustermann",
+	}, suite.router, suite.T())
+	require.NotNil(suite.T(), accessTokenCookie)
+
+	// an access token has no session and must not be usable as a refresh token
+	request := httptest.NewRequest("POST", "/users/token/refresh", nil)
+	request.AddCookie(&http.Cookie{
+		Name:  "refresh_token",
+		Value: accessTokenCookie.Value,
+	})
+	recorder := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+
+	require.Equal(suite.T(), 401, recorder.Result().StatusCode)
+}
+
+func (suite *UserControllerTestSuite) TestLogoutUser() {
+	registerUserParam := &dto.RegisterUserDto{
+		Email:     "Max@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Max",
+		LastName:  "Mustermann",
+	}
+	accessTokenCookie1 := registerUserAndLogin(registerUserParam, suite.router, suite.T())
+	accessTokenCookie2 := loginUser(&dto.LoginUserDto{
+		Email:    registerUserParam.Email,
+		Password: registerUserParam.Password,
+	}, suite.router, suite.T())
+
+	request := httptest.NewRequest("POST", "/users/logout", nil)
+	request.AddCookie(accessTokenCookie1)
+	recorder := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusNoContent, recorder.Result().StatusCode)
+
+	// the access token of the logged out session is refused
+	request = httptest.NewRequest("POST", "/users/logout", nil)
+	request.AddCookie(accessTokenCookie1)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusUnauthorized, recorder.Result().StatusCode)
+
+	// other sessions are still valid
+	request = httptest.NewRequest("POST", "/users/logout", nil)
+	request.AddCookie(accessTokenCookie2)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusNoContent, recorder.Result().StatusCode)
+}
+
+func (suite *UserControllerTestSuite) TestLogoutUserEverywhere() {
+	registerUserParam := &dto.RegisterUserDto{
+		Email:     "Max@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Max",
+		LastName:  "Mustermann",
+	}
+	accessTokenCookie1 := registerUserAndLogin(registerUserParam, suite.router, suite.T())
+	accessTokenCookie2 := loginUser(&dto.LoginUserDto{
+		Email:    registerUserParam.Email,
+		Password: registerUserParam.Password,
+	}, suite.router, suite.T())
+
+	request := httptest.NewRequest("POST", "/users/logout/all", nil)
+	request.AddCookie(accessTokenCookie1)
+	recorder := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusNoContent, recorder.Result().StatusCode)
+
+	request = httptest.NewRequest("POST", "/users/logout", nil)
+	request.AddCookie(accessTokenCookie2)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusUnauthorized, recorder.Result().StatusCode)
+}
+
+func (suite *UserControllerTestSuite) TestRevokeUserSessions() {
+	customerParam := &dto.RegisterUserDto{
+		Email:     "Max@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Max",
+		LastName:  "Mustermann",
+	}
+	customerAccessToken := registerUserAndLogin(customerParam, suite.router, suite.T())
+
+	hashedPasswordBytes, err := bcrypt.GenerateFromPassword([]byte("Test1234"), bcrypt.DefaultCost)
+	require.NoError(suite.T(), err)
+
+	banker, err := testStore.RegisterUser(suite.ctx, &db.RegisterUserParams{
+		Email:          "Tom@Mustermann.de",
+		HashedPassword: string(hashedPasswordBytes),
+		FirstName:      "Tom",
+		LastName:       "Mustermann",
+		UserRole:       utils.BankerRole,
+	})
+	require.NoError(suite.T(), err)
+
+	bankerAccessToken := loginUser(&dto.LoginUserDto{
+		Email:    banker.Email,
+		Password: "Test1234",
+	}, suite.router, suite.T())
+
+	// customers cannot revoke sessions
+	request := httptest.NewRequest("DELETE", "/users/"+banker.Email+"/sessions", nil)
+	request.AddCookie(customerAccessToken)
+	recorder := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusUnauthorized, recorder.Result().StatusCode)
+
+	request = httptest.NewRequest("DELETE", "/users/"+customerParam.Email+"/sessions", nil)
+	request.AddCookie(bankerAccessToken)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusNoContent, recorder.Result().StatusCode)
+
+	request = httptest.NewRequest("POST", "/users/logout", nil)
+	request.AddCookie(customerAccessToken)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusUnauthorized, recorder.Result().StatusCode)
+}
+
+func (suite *UserControllerTestSuite) TestProtectedRouteFailWithRefreshToken() {
+	registerUserParam := &dto.RegisterUserDto{
+		Email:     "Max@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Max",
+		LastName:  "Mustermann",
+	}
+	registerUserAndLogin(registerUserParam, suite.router, suite.T())
+
+	loginRequestParamBytes, err := json.Marshal(&dto.LoginUserDto{
+		Email:    registerUserParam.Email,
+		Password: registerUserParam.Password,
+	})
+	require.NoError(suite.T(), err)
+
+	request := httptest.NewRequest("POST", "/users/login", bytes.NewReader(loginRequestParamBytes))
+	recorder := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), 200, recorder.Result().StatusCode)
+
+	refreshTokenCookie := getCookie(recorder.Result().Cookies(), "refresh_token")
+	require.NotNil(suite.T(), refreshTokenCookie)
+
+	// the refresh token carries the same session as the access token but must not authorize requests
+	request = httptest.NewRequest("GET", "/users/me/sessions", nil)
+	request.Header.Set("Authorization", "Bearer "+refreshTokenCookie.Value)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusUnauthorized, recorder.Result().StatusCode)
+
+	request = httptest.NewRequest("GET", "/users/me/sessions", nil)
+	request.AddCookie(&http.Cookie{
+		Name:  "access_token",
+		Value: refreshTokenCookie.Value,
+	})
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusUnauthorized, recorder.Result().StatusCode)
+}
+
+func (suite *UserControllerTestSuite) TestListAndRevokeSessions() {
+	registerUserParam := &dto.RegisterUserDto{
+		Email:     "Max@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Max",
+		LastName:  "Mustermann",
+	}
+	accessTokenCookie1 := registerUserAndLogin(registerUserParam, suite.router, suite.T())
+	accessTokenCookie2 := loginUser(&dto.LoginUserDto{
+		Email:    registerUserParam.Email,
+		Password: registerUserParam.Password,
+	}, suite.router, suite.T())
+
+	request := httptest.NewRequest("GET", "/users/me/sessions", nil)
+	request.AddCookie(accessTokenCookie1)
+	recorder := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusOK, recorder.Result().StatusCode)
+
+	var sessions []*dto.SessionDto
+	err := json.NewDecoder(recorder.Result().Body).Decode(&sessions)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), sessions, 2)
+
+	// revoke the session that is not the current one
+	var otherSession *dto.SessionDto
+	for _, session := range sessions {
+		require.Equal(suite.T(), "test", session.UserAgent)
+		if !session.Current {
+			otherSession = session
+		}
+	}
+	require.NotNil(suite.T(), otherSession)
+
+	request = httptest.NewRequest("DELETE", "/users/me/sessions/"+otherSession.ID.String(), nil)
+	request.AddCookie(accessTokenCookie1)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusNoContent, recorder.Result().StatusCode)
+
+	request = httptest.NewRequest("GET", "/users/me/sessions", nil)
+	request.AddCookie(accessTokenCookie2)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusUnauthorized, recorder.Result().StatusCode)
+}
+
+func (suite *UserControllerTestSuite) TestRevokeSessionOfOtherUser() {
+	accessTokenCookie1 := registerUserAndLogin(&dto.RegisterUserDto{
+		Email:     "Max@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Max",
+		LastName:  "Mustermann",
+	}, suite.router, suite.T())
+	accessTokenCookie2 := registerUserAndLogin(&dto.RegisterUserDto{
+		Email:     "Tom@Mustermann.de",
+		Password:  "Test1234",
+		FirstName: "Tom",
+		LastName:  "Mustermann",
+	}, suite.router, suite.T())
+
+	request := httptest.NewRequest("GET", "/users/me/sessions", nil)
+	request.AddCookie(accessTokenCookie2)
+	recorder := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusOK, recorder.Result().StatusCode)
+
+	var sessions []*dto.SessionDto
+	err := json.NewDecoder(recorder.Result().Body).Decode(&sessions)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), sessions, 1)
+
+	request = httptest.NewRequest("DELETE", "/users/me/sessions/"+sessions[0].ID.String(), nil)
+	request.AddCookie(accessTokenCookie1)
+	recorder = httptest.NewRecorder()
+
+	suite.router.ServeHTTP(recorder, request)
+	require.Equal(suite.T(), http.StatusNotFound, recorder.Result().StatusCode)
+}
+
 // helper function for test suits that need users
 func registerUserAndLogin(arg *dto.RegisterUserDto, router http.Handler, t *testing.T) *http.Cookie {
 	// register user
@@ -306,15 +648,15 @@ func registerUserAndLogin(arg *dto.RegisterUserDto, router http.Handler, t *test
 
 	require.Equal(t, 200, recorder.Result().StatusCode)
 
-	accessTokenCookie := getCookie(recorder.Result().Cookies())
+	accessTokenCookie := getCookie(recorder.Result().Cookies(), "access_token")
 	require.NotNil(t, accessTokenCookie)
 
 	return accessTokenCookie
 }
 
-func getCookie(cookies []*http.Cookie) *http.Cookie {
+func getCookie(cookies []*http.Cookie, name string) *http.Cookie {
 	for _, cookie := range cookies {
-		if cookie.Name == "access_token" {
+		if cookie.Name == name {
 			return cookie
 		}
 	}
@@ -344,7 +686,7 @@ func loginUser(arg *dto.LoginUserDto, router http.Handler, t *testing.T) *http.C
 
 	require.Equal(t, 200, recorder.Result().StatusCode)
 
-	accessTokenCookie := getCookie(recorder.Result().Cookies())
+	accessTokenCookie := getCookie(recorder.Result().Cookies(), "access_token")
 	require.NotNil(t, accessTokenCookie)
 
 	return accessTokenCookie
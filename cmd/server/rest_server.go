@@ -15,6 +15,9 @@ func InitHttpServer(
 	userService services.UserServiceInterface,
 	accountService services.AccountServiceInterface,
 	transferService services.TransferServiceInterface,
+	standingOrderService services.StandingOrderServiceInterface,
+	holdService services.HoldServiceInterface,
+	ledgerService services.LedgerServiceInterface,
 	tokenMaker utils.TokenMaker,
 ) *http.Server {
 	// init validator
@@ -24,24 +27,63 @@ func InitHttpServer(
 	userController := rest.NewUserController(userService, validator)
 	accountController := rest.NewAccountController(accountService, validator)
 	transferController := rest.NewTransferController(transferService, validator)
+	standingOrderController := rest.NewStandingOrderController(standingOrderService, validator)
+	holdController := rest.NewHoldController(holdService, validator)
+	ledgerController := rest.NewLedgerController(ledgerService, validator)
+	tokenController := rest.NewTokenController(tokenMaker)
 
 	// setup router
-	router := http.NewServeMux()
+	router := utils.NewRouteRegistry()
 
-	router.HandleFunc("POST /users/register", userController.HandleRegisterUser)
-	router.HandleFunc("POST /users/login", userController.HandleLoginUser)
+	router.HandleFunc("POST /users/register", utils.PublicRoute(), userController.HandleRegisterUser)
+	router.HandleFunc("POST /users/login", utils.PublicRoute(), userController.HandleLoginUser)
+	router.HandleFunc("POST /users/token/refresh", utils.PublicRoute(), userController.HandleRefreshToken)
+	router.HandleFunc("POST /users/logout", utils.AllowRoles(utils.AllRoles...), userController.HandleLogoutUser)
+	router.HandleFunc("POST /users/logout/all", utils.AllowRoles(utils.AllRoles...), userController.HandleLogoutUserEverywhere)
+	router.HandleFunc("DELETE /users/{email}/sessions", utils.AllowRoles(utils.BankerRole, utils.AdminRole), userController.HandleRevokeUserSessions)
+	router.HandleFunc("GET /users/me/sessions", utils.AllowRoles(utils.AllRoles...), userController.HandleListSessions)
+	router.HandleFunc("DELETE /users/me/sessions/{id}", utils.AllowRoles(utils.AllRoles...), userController.HandleRevokeSession)
 
-	router.HandleFunc("POST /accounts", accountController.HandleCreateAccount)
-	router.HandleFunc("GET /accounts/{id}", accountController.HandleGetAccount)
-	router.HandleFunc("GET /accounts", accountController.HandleListAccounts)
+	router.HandleFunc("POST /accounts", utils.AllowRoles(utils.CustomerRole), accountController.HandleCreateAccount)
+	router.HandleFunc("GET /accounts/{id}", utils.AllowRoles(utils.AllRoles...), accountController.HandleGetAccount)
+	router.HandleFunc("GET /accounts", utils.AllowRoles(utils.BankerRole, utils.AdminRole), accountController.HandleListAccounts)
+	router.HandleFunc("GET /users/me/accounts", utils.AllowRoles(utils.AllRoles...), accountController.HandleListOwnAccounts)
+	router.HandleFunc("PUT /accounts/{id}/overdraft-limit", utils.AllowRoles(utils.BankerRole, utils.AdminRole), accountController.HandleSetOverdraftLimit)
+	router.HandleFunc("GET /accounts/{id}/transfers", utils.AllowRoles(utils.AllRoles...), transferController.HandleListTransfers)
+	router.HandleFunc("GET /accounts/{id}/entries", utils.AllowRoles(utils.AllRoles...), accountController.HandleListEntries)
+	router.HandleFunc("GET /accounts/{id}/balance", utils.AllowRoles(utils.AllRoles...), accountController.HandleGetAccountBalance)
+	router.HandleFunc("POST /accounts/{id}/holds", utils.AllowRoles(utils.BankerRole, utils.AdminRole), holdController.HandleCreateHold)
+	router.HandleFunc("GET /accounts/{id}/holds", utils.AllowRoles(utils.AllRoles...), holdController.HandleListHolds)
 
-	router.HandleFunc("POST /transfers", transferController.HandleCreateTransfer)
+	router.HandleFunc("GET /holds/{id}", utils.AllowRoles(utils.AllRoles...), holdController.HandleGetHold)
+	router.HandleFunc("POST /holds/{id}/capture", utils.AllowRoles(utils.BankerRole, utils.AdminRole), holdController.HandleCaptureHold)
+	router.HandleFunc("POST /holds/{id}/release", utils.AllowRoles(utils.BankerRole, utils.AdminRole), holdController.HandleReleaseHold)
 
-	// init protected routes
-	utils.SetProtectedRoutes()
+	router.HandleFunc("POST /transfers", utils.AllowRoles(utils.CustomerRole), transferController.HandleCreateTransfer)
+	router.HandleFunc("GET /transfers/{id}", utils.AllowRoles(utils.AllRoles...), transferController.HandleGetTransfer)
+	router.HandleFunc("POST /transfers/{id}/reversals", utils.AllowRoles(utils.BankerRole, utils.AdminRole), transferController.HandleReverseTransfer)
+
+	router.HandleFunc("GET /transfer-approvals", utils.AllowRoles(utils.BankerRole, utils.AdminRole), transferController.HandleListTransferApprovals)
+	router.HandleFunc("GET /transfer-approvals/{id}", utils.AllowRoles(utils.AllRoles...), transferController.HandleGetTransferApproval)
+	router.HandleFunc("POST /transfer-approvals/{id}/approve", utils.AllowRoles(utils.BankerRole), transferController.HandleApproveTransfer)
+	router.HandleFunc("POST /transfer-approvals/{id}/reject", utils.AllowRoles(utils.BankerRole), transferController.HandleRejectTransfer)
+
+	router.HandleFunc("POST /standing-orders", utils.AllowRoles(utils.CustomerRole), standingOrderController.HandleCreateStandingOrder)
+	router.HandleFunc("GET /standing-orders", utils.AllowRoles(utils.CustomerRole), standingOrderController.HandleListStandingOrders)
+	router.HandleFunc("GET /standing-orders/{id}", utils.AllowRoles(utils.AllRoles...), standingOrderController.HandleGetStandingOrder)
+	router.HandleFunc("PUT /standing-orders/{id}", utils.AllowRoles(utils.CustomerRole), standingOrderController.HandleUpdateStandingOrder)
+	router.HandleFunc("DELETE /standing-orders/{id}", utils.AllowRoles(utils.CustomerRole), standingOrderController.HandleCancelStandingOrder)
+
+	router.HandleFunc("GET /ledger/accounts", utils.AllowRoles(utils.BankerRole, utils.AdminRole), ledgerController.HandleListLedgerAccounts)
+	router.HandleFunc("POST /ledger/accounts", utils.AllowRoles(utils.AdminRole), ledgerController.HandleCreateLedgerAccount)
+	router.HandleFunc("POST /ledger/journals", utils.AllowRoles(utils.AdminRole), ledgerController.HandlePostJournal)
+	router.HandleFunc("GET /ledger/journals/{id}", utils.AllowRoles(utils.BankerRole, utils.AdminRole), ledgerController.HandleGetJournal)
+	router.HandleFunc("GET /ledger/trial-balance", utils.AllowRoles(utils.BankerRole, utils.AdminRole), ledgerController.HandleGetTrialBalance)
+
+	router.HandleFunc("GET /token/keys", utils.PublicRoute(), tokenController.HandleGetPublicKeys)
 
 	return &http.Server{
 		Addr:    port,
-		Handler: middlewares.AuthMiddleware(tokenMaker, router),
+		Handler: middlewares.AuthMiddleware(tokenMaker, userService, router),
 	}
 }
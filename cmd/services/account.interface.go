@@ -11,7 +11,15 @@ type AccountServiceInterface interface {
 
 	GetAccount(ctx context.Context, id int64, owner string, role string) (*db.Account, *dto.ResponseError)
 
-	ListAccounts(ctx context.Context, args *dto.ListAccountsDto, role string) ([]*db.Account, *dto.ResponseError)
+	ListAccounts(ctx context.Context, args *dto.ListAccountsDto, role string) (*dto.PageDto[*db.Account], *dto.ResponseError)
+
+	ListOwnAccounts(ctx context.Context, args *dto.ListAccountsDto, email string) (*dto.PageDto[*db.Account], *dto.ResponseError)
+
+	ListEntries(ctx context.Context, args *dto.ListEntriesDto, email string, role string) (*dto.PageDto[*db.Entry], *dto.ResponseError)
+
+	GetAccountBalance(ctx context.Context, args *dto.GetAccountBalanceDto, email string, role string) (*dto.AccountBalanceDto, *dto.ResponseError)
+
+	SetOverdraftLimit(ctx context.Context, args *dto.SetOverdraftLimitDto, role string) (*db.Account, *dto.ResponseError)
 }
 
 var _ AccountServiceInterface = (*AccountServiceImpl)(nil)
@@ -22,13 +22,23 @@ func NewAccountService(store db.Store) *AccountServiceImpl {
 }
 
 func (a *AccountServiceImpl) CreateAccount(ctx context.Context, args *dto.CreateAccountDto) (*db.Account, *dto.ResponseError) {
-	createAccountParams := &db.CreateAccountParams{
-		Owner:    args.Owner,
-		Currency: args.Currency,
-		Balance:  0,
+	return runIdempotent(ctx, a.store, args.Owner, args.IdempotencyKey, "CreateAccount", args, func(complete func(q *db.Queries, result *db.Account) error) (*db.Account, *dto.ResponseError) {
+		return a.createAccount(ctx, args, complete)
+	})
+}
+
+// createAccount creates the account, afterCreate runs within the database transaction of the creation
+func (a *AccountServiceImpl) createAccount(ctx context.Context, args *dto.CreateAccountDto, afterCreate func(q *db.Queries, account *db.Account) error) (*db.Account, *dto.ResponseError) {
+	createAccountParams := db.CreateAccountTxParams{
+		CreateAccountParams: db.CreateAccountParams{
+			Owner:    args.Owner,
+			Currency: args.Currency,
+			Balance:  0,
+		},
+		AfterCreate: afterCreate,
 	}
 
-	createdAccount, err := a.store.CreateAccount(ctx, createAccountParams)
+	createdAccount, err := a.store.CreateAccountTx(ctx, createAccountParams)
 
 	if err != nil {
 		if db.ErrorCode(err) == db.UniqueViolation {
@@ -72,7 +82,7 @@ func (a *AccountServiceImpl) GetAccount(ctx context.Context, id int64, email str
 	}
 }
 
-func (a AccountServiceImpl) ListAccounts(ctx context.Context, arg *dto.ListAccountsDto, role string) ([]*db.Account, *dto.ResponseError) {
+func (a AccountServiceImpl) ListAccounts(ctx context.Context, arg *dto.ListAccountsDto, role string) (*dto.PageDto[*db.Account], *dto.ResponseError) {
 	if role != utils.AdminRole && role != utils.BankerRole {
 		return nil, &dto.ResponseError{
 			Message: "You have no permission for this action",
@@ -80,9 +90,15 @@ func (a AccountServiceImpl) ListAccounts(ctx context.Context, arg *dto.ListAccou
 		}
 	}
 
+	afterId, respErr := decodeCursor(arg.Cursor)
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
 	params := &db.ListAccountsParams{
-		Limit:  arg.Limit,
-		Offset: arg.Offset,
+		AfterID: afterId,
+		Limit:   arg.Limit + 1,
 	}
 
 	accountList, err := a.store.ListAccounts(ctx, params)
@@ -94,7 +110,116 @@ func (a AccountServiceImpl) ListAccounts(ctx context.Context, arg *dto.ListAccou
 		}
 	}
 
-	return accountList, nil
+	return newPage(accountList, arg.Limit, func(account *db.Account) int64 { return account.ID }), nil
+}
+
+func (a *AccountServiceImpl) ListOwnAccounts(ctx context.Context, arg *dto.ListAccountsDto, email string) (*dto.PageDto[*db.Account], *dto.ResponseError) {
+	afterId, respErr := decodeCursor(arg.Cursor)
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	params := &db.ListAccountsByOwnerParams{
+		Owner:   email,
+		AfterID: afterId,
+		Limit:   arg.Limit + 1,
+	}
+
+	accountList, err := a.store.ListAccountsByOwner(ctx, params)
+
+	if err != nil {
+		return nil, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	return newPage(accountList, arg.Limit, func(account *db.Account) int64 { return account.ID }), nil
+}
+
+func (a *AccountServiceImpl) ListEntries(ctx context.Context, arg *dto.ListEntriesDto, email string, role string) (*dto.PageDto[*db.Entry], *dto.ResponseError) {
+	respErr := validTimeRange(arg.StartTime, arg.EndTime)
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	afterId, respErr := decodeCursor(arg.Cursor)
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	_, respErr = a.GetAccount(ctx, arg.AccountId, email, role)
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	outgoing, incoming := directionFilter(arg.Direction)
+
+	params := &db.ListEntriesParams{
+		AccountID: arg.AccountId,
+		Outgoing:  outgoing,
+		Incoming:  incoming,
+		StartTime: arg.StartTime,
+		EndTime:   arg.EndTime,
+		AfterID:   afterId,
+		Limit:     arg.Limit + 1,
+	}
+
+	entryList, err := a.store.ListEntries(ctx, params)
+
+	if err != nil {
+		return nil, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	return newPage(entryList, arg.Limit, func(entry *db.Entry) int64 { return entry.ID }), nil
+}
+
+func (a *AccountServiceImpl) SetOverdraftLimit(ctx context.Context, arg *dto.SetOverdraftLimitDto, role string) (*db.Account, *dto.ResponseError) {
+	if role != utils.AdminRole && role != utils.BankerRole {
+		return nil, &dto.ResponseError{
+			Message: "You have no permission for this action",
+			Status:  http.StatusUnauthorized,
+		}
+	}
+
+	params := &db.UpdateAccountOverdraftLimitParams{
+		ID:             arg.AccountId,
+		OverdraftLimit: arg.OverdraftLimit,
+	}
+
+	account, err := a.store.UpdateAccountOverdraftLimit(ctx, params)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &dto.ResponseError{
+				Message: err.Error(),
+				Status:  http.StatusNotFound,
+			}
+		}
+
+		// lowering the limit below the current debt violates the balance constraint
+		if db.ErrorCode(err) == db.CheckViolation {
+			return nil, &dto.ResponseError{
+				Message: "overdraft limit is lower than the current debt of the account",
+				Status:  http.StatusUnprocessableEntity,
+				Reason:  dto.ReasonOverdraftLimitBelowDebt,
+			}
+		}
+
+		return nil, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	return account, nil
 }
 
 var _ AccountServiceInterface = (*AccountServiceImpl)(nil)
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"errors"
+	db "kara-bank/db/repositories"
+	"kara-bank/dto"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// balanceSnapshotSettleDelay is how long a day must be over before its snapshot is built. Entries get the start time
+	// of their transaction, so a transaction that is still running at midnight can add entries to the previous day.
+	balanceSnapshotSettleDelay = 10 * time.Minute
+	// balanceSnapshotBatchSize is the maximum number of days snapshotted per run of the snapshot worker
+	balanceSnapshotBatchSize = 100
+)
+
+// GetAccountBalance returns the balance of the account at the given time. It starts from the latest daily snapshot
+// before that time and adds the entries created since, or sums all entries if there is no snapshot yet.
+func (a *AccountServiceImpl) GetAccountBalance(ctx context.Context, arg *dto.GetAccountBalanceDto, email string, role string) (*dto.AccountBalanceDto, *dto.ResponseError) {
+	if arg.At.After(time.Now()) {
+		return nil, &dto.ResponseError{
+			Message: "at must not be in the future",
+			Status:  http.StatusBadRequest,
+		}
+	}
+
+	account, respErr := a.GetAccount(ctx, arg.AccountId, email, role)
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	var balance int64
+	var startTime *time.Time
+
+	snapshot, err := a.store.GetBalanceSnapshotBefore(ctx, &db.GetBalanceSnapshotBeforeParams{
+		AccountID: arg.AccountId,
+		At:        arg.At,
+	})
+
+	if err == nil {
+		balance, startTime = snapshot.Balance, &snapshot.EndTime
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	total, err := a.store.SumEntriesUntil(ctx, &db.SumEntriesUntilParams{
+		AccountID: arg.AccountId,
+		StartTime: startTime,
+		At:        arg.At,
+	})
+
+	if err != nil {
+		return nil, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	return &dto.AccountBalanceDto{
+		AccountId: account.ID,
+		Currency:  account.Currency,
+		Balance:   balance + total,
+		At:        arg.At,
+	}, nil
+}
+
+// BuildBalanceSnapshots snapshots the balances of all accounts at the end of every day after the latest snapshot, up to
+// a batch of days, and returns the number of snapshotted days. The first snapshot is taken at the end of the day of the
+// first entry. Snapshots only speed up GetAccountBalance, the entries stay the source of the balances.
+func (a *AccountServiceImpl) BuildBalanceSnapshots(ctx context.Context, now time.Time) (int, error) {
+	var previousEndTime time.Time
+
+	latest, err := a.store.GetLatestBalanceSnapshotTime(ctx)
+
+	if err == nil {
+		previousEndTime = latest
+	} else if errors.Is(err, pgx.ErrNoRows) {
+		firstEntry, err := a.store.GetFirstEntryTime(ctx)
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+
+		if err != nil {
+			return 0, err
+		}
+
+		// the day before the first entry has no snapshot, so the first snapshot sums all entries
+		previousEndTime = startOfDay(firstEntry)
+	} else {
+		return 0, err
+	}
+
+	days := 0
+
+	for endTime := previousEndTime.AddDate(0, 0, 1); days < balanceSnapshotBatchSize; endTime = endTime.AddDate(0, 0, 1) {
+		if endTime.After(now.Add(-balanceSnapshotSettleDelay)) {
+			break
+		}
+
+		_, err = a.store.CreateBalanceSnapshots(ctx, &db.CreateBalanceSnapshotsParams{
+			EndTime:         endTime,
+			PreviousEndTime: previousEndTime,
+		})
+
+		if err != nil {
+			return days, err
+		}
+
+		previousEndTime = endTime
+		days++
+	}
+
+	return days, nil
+}
+
+// startOfDay returns midnight in UTC of the day of the given time
+func startOfDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
@@ -0,0 +1,24 @@
+package services
+
+import (
+	"kara-bank/dto"
+	"net/http"
+	"time"
+)
+
+// directionFilter translates the optional direction of a history request into the query flags.
+// Without a direction both incoming and outgoing transactions are listed.
+func directionFilter(direction string) (outgoing bool, incoming bool) {
+	return direction != dto.DirectionIncoming, direction != dto.DirectionOutgoing
+}
+
+func validTimeRange(startTime *time.Time, endTime *time.Time) *dto.ResponseError {
+	if startTime != nil && endTime != nil && !endTime.After(*startTime) {
+		return &dto.ResponseError{
+			Message: "end time must be after start time",
+			Status:  http.StatusBadRequest,
+		}
+	}
+
+	return nil
+}
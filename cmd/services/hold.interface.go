@@ -0,0 +1,24 @@
+package services
+
+import (
+	"context"
+	db "kara-bank/db/repositories"
+	"kara-bank/dto"
+)
+
+type HoldServiceInterface interface {
+	CreateHold(ctx context.Context, arg *dto.CreateHoldDto) (*db.Hold, *dto.ResponseError)
+
+	GetHold(ctx context.Context, id int64, email string, role string) (*db.Hold, *dto.ResponseError)
+
+	ListHolds(ctx context.Context, arg *dto.ListHoldsDto, email string, role string) (*dto.PageDto[*db.Hold], *dto.ResponseError)
+
+	CaptureHold(ctx context.Context, arg *dto.CaptureHoldDto) (*db.CaptureHoldTxResult, *dto.ResponseError)
+
+	ReleaseHold(ctx context.Context, id int64) (*db.Hold, *dto.ResponseError)
+}
+
+// holdConversions is the part of the transfer service that converts captured amounts between currencies
+type holdConversions interface {
+	convertAmount(ctx context.Context, arg *db.TransferTxParams, fromCurrency string, toCurrency string) *dto.ResponseError
+}
@@ -0,0 +1,307 @@
+package services
+
+import (
+	"context"
+	"errors"
+	db "kara-bank/db/repositories"
+	"kara-bank/dto"
+	"kara-bank/utils"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// defaultHoldDuration is how long a hold reserves money if no expiry time is given
+	defaultHoldDuration = 7 * 24 * time.Hour
+	// holdExpiryBatchSize is the maximum number of holds expired per run of the expiry worker
+	holdExpiryBatchSize = 100
+)
+
+type HoldServiceImpl struct {
+	store db.Store
+	// converts captured amounts between currencies
+	transferService holdConversions
+}
+
+func NewHoldService(store db.Store, transferService holdConversions) *HoldServiceImpl {
+	return &HoldServiceImpl{
+		store:           store,
+		transferService: transferService,
+	}
+}
+
+// CreateHold reserves money on an account, e.g. for a card authorization, until the hold is captured, released or expires
+func (h *HoldServiceImpl) CreateHold(ctx context.Context, arg *dto.CreateHoldDto) (*db.Hold, *dto.ResponseError) {
+	expiresAt := time.Now().Add(defaultHoldDuration)
+
+	if arg.ExpiresAt != nil {
+		if !arg.ExpiresAt.After(time.Now()) {
+			return nil, &dto.ResponseError{
+				Message: "expiry time must be in the future",
+				Status:  http.StatusBadRequest,
+			}
+		}
+
+		expiresAt = *arg.ExpiresAt
+	}
+
+	_, respErr := h.getAccount(ctx, arg.AccountId)
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	hold, err := h.store.CreateHoldTx(ctx, db.CreateHoldParams{
+		AccountID:   arg.AccountId,
+		Amount:      arg.Amount,
+		Description: arg.Description,
+		ExpiresAt:   &expiresAt,
+	})
+
+	if err != nil {
+		return nil, transferError(err)
+	}
+
+	return hold, nil
+}
+
+func (h *HoldServiceImpl) GetHold(ctx context.Context, id int64, email string, role string) (*db.Hold, *dto.ResponseError) {
+	hold, respErr := h.getHold(ctx, id)
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	account, respErr := h.getAccount(ctx, hold.AccountID)
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	if account.Owner != email && role != utils.BankerRole && role != utils.AdminRole {
+		return nil, &dto.ResponseError{
+			Message: "You have no permission for this hold",
+			Status:  http.StatusUnauthorized,
+		}
+	}
+
+	return hold, nil
+}
+
+func (h *HoldServiceImpl) ListHolds(ctx context.Context, arg *dto.ListHoldsDto, email string, role string) (*dto.PageDto[*db.Hold], *dto.ResponseError) {
+	afterId, respErr := decodeCursor(arg.Cursor)
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	account, respErr := h.getAccount(ctx, arg.AccountId)
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	if account.Owner != email && role != utils.BankerRole && role != utils.AdminRole {
+		return nil, &dto.ResponseError{
+			Message: "You have no permission for this account",
+			Status:  http.StatusUnauthorized,
+		}
+	}
+
+	holdList, err := h.store.ListHoldsByAccount(ctx, &db.ListHoldsByAccountParams{
+		AccountID: account.ID,
+		AfterID:   afterId,
+		Limit:     arg.Limit + 1,
+	})
+
+	if err != nil {
+		return nil, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	return newPage(holdList, arg.Limit, func(hold *db.Hold) int64 { return hold.ID }), nil
+}
+
+// CaptureHold transfers the captured amount from the held account to the receiving account and releases the rest of the hold
+func (h *HoldServiceImpl) CaptureHold(ctx context.Context, arg *dto.CaptureHoldDto) (*db.CaptureHoldTxResult, *dto.ResponseError) {
+	hold, respErr := h.getHold(ctx, arg.HoldId)
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	if hold.AccountID == arg.ToAccountId {
+		return nil, &dto.ResponseError{
+			Message: "Holds cannot be captured to the held account",
+			Status:  http.StatusBadRequest,
+		}
+	}
+
+	fromAccount, respErr := h.getAccount(ctx, hold.AccountID)
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	toAccount, err := h.store.GetAccount(ctx, arg.ToAccountId)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &dto.ResponseError{
+				Message: "toAccount not found",
+				Status:  http.StatusNotFound,
+			}
+		}
+
+		return nil, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	conversion := db.TransferTxParams{Amount: arg.Amount}
+
+	if conversion.Amount == 0 {
+		conversion.Amount = hold.Amount
+	}
+
+	if fromAccount.Currency != toAccount.Currency {
+		respErr = h.transferService.convertAmount(ctx, &conversion, fromAccount.Currency, toAccount.Currency)
+
+		if respErr != nil {
+			return nil, respErr
+		}
+	}
+
+	result, err := h.store.CaptureHoldTx(ctx, db.CaptureHoldTxParams{
+		HoldID:       hold.ID,
+		ToAccountID:  toAccount.ID,
+		Amount:       conversion.Amount,
+		ToAmount:     conversion.ToAmount,
+		ExchangeRate: conversion.ExchangeRate,
+	})
+
+	if err != nil {
+		return nil, holdError(err)
+	}
+
+	return &result, nil
+}
+
+// ReleaseHold makes the held amount available again without transferring it
+func (h *HoldServiceImpl) ReleaseHold(ctx context.Context, id int64) (*db.Hold, *dto.ResponseError) {
+	hold, err := h.store.ReleaseHoldTx(ctx, id)
+
+	if err != nil {
+		return nil, holdError(err)
+	}
+
+	return hold, nil
+}
+
+// ExpireHolds releases a batch of holds whose expiry time has passed and returns the number of processed holds.
+// Holds that were captured or released in the meantime are skipped.
+func (h *HoldServiceImpl) ExpireHolds(ctx context.Context, now time.Time) (int, error) {
+	holds, err := h.store.ListExpiredHolds(ctx, &db.ListExpiredHoldsParams{
+		Now:   now,
+		Limit: holdExpiryBatchSize,
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+
+	for _, hold := range holds {
+		_, err = h.store.ExpireHoldTx(ctx, hold.ID, now)
+
+		if err != nil && !errors.Is(err, db.ErrHoldClosed) {
+			errs = append(errs, err)
+		}
+	}
+
+	return len(holds), errors.Join(errs...)
+}
+
+func (h *HoldServiceImpl) getHold(ctx context.Context, id int64) (*db.Hold, *dto.ResponseError) {
+	hold, err := h.store.GetHold(ctx, id)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &dto.ResponseError{
+				Message: err.Error(),
+				Status:  http.StatusNotFound,
+			}
+		}
+
+		return nil, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	return hold, nil
+}
+
+func (h *HoldServiceImpl) getAccount(ctx context.Context, id int64) (*db.Account, *dto.ResponseError) {
+	account, err := h.store.GetAccount(ctx, id)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &dto.ResponseError{
+				Message: err.Error(),
+				Status:  http.StatusNotFound,
+			}
+		}
+
+		return nil, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	return account, nil
+}
+
+// holdError maps the errors of capturing or releasing a hold to responses
+func holdError(err error) *dto.ResponseError {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusNotFound,
+		}
+	}
+
+	if errors.Is(err, db.ErrHoldClosed) {
+		return &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusConflict,
+			Reason:  dto.ReasonHoldClosed,
+		}
+	}
+
+	if errors.Is(err, db.ErrHoldOfTransferApproval) {
+		return &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusConflict,
+			Reason:  dto.ReasonApprovalRequired,
+		}
+	}
+
+	if errors.Is(err, db.ErrInvalidCaptureAmount) {
+		return &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusUnprocessableEntity,
+			Reason:  dto.ReasonInvalidCaptureAmount,
+		}
+	}
+
+	return transferError(err)
+}
+
+var _ HoldServiceInterface = (*HoldServiceImpl)(nil)
@@ -0,0 +1,238 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	db "kara-bank/db/repositories"
+	"kara-bank/dto"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// idempotencyKeyRetention is how long a completed request can be replayed with its idempotency key
+	idempotencyKeyRetention = 24 * time.Hour
+	// idempotencyKeyLease is how long a request reserves its key. A reservation that was neither completed nor released,
+	// e.g. because the app crashed, is taken over by the next request with the key after the lease
+	idempotencyKeyLease = time.Minute
+	// idempotencyKeyReleaseTimeout limits the release of a key, which also runs after the request was cancelled
+	idempotencyKeyReleaseTimeout = 5 * time.Second
+)
+
+// idempotentRequest deduplicates requests that carry an idempotency key. The key is reserved before the request
+// is executed and the serialized result is stored within the transaction of the request, so that a retry returns
+// the original result instead of executing the request a second time.
+type idempotentRequest struct {
+	store       db.Store
+	email       string
+	key         string
+	requestHash string
+}
+
+// runIdempotent executes fn at most once per idempotency key and payload. Without a key fn is always executed.
+// fn passes its result to complete within its database transaction, so that the result is stored if and only if
+// the request is committed.
+func runIdempotent[T any](
+	ctx context.Context,
+	store db.Store,
+	email string,
+	key string,
+	operation string,
+	payload any,
+	fn func(complete func(q *db.Queries, result *T) error) (*T, *dto.ResponseError),
+) (*T, *dto.ResponseError) {
+	request, respErr := newIdempotentRequest(store, email, key, operation, payload)
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	if request == nil {
+		return fn(func(q *db.Queries, result *T) error { return nil })
+	}
+
+	var storedResult T
+	replayed, respErr := request.begin(ctx, &storedResult)
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	if replayed {
+		return &storedResult, nil
+	}
+
+	completed := false
+
+	result, respErr := fn(func(q *db.Queries, result *T) error {
+		err := request.complete(ctx, q, result)
+		completed = err == nil
+		return err
+	})
+
+	if respErr != nil {
+		request.release(ctx)
+		return nil, respErr
+	}
+
+	// fallback for requests without a transaction. The request has been executed, so its result is returned even
+	// if it cannot be stored. The key is released, so that retries are not rejected as in progress until it expires
+	if !completed {
+		err := request.complete(ctx, store, result)
+
+		if err != nil {
+			log.Printf("cannot store the result of idempotency key %q: %v", key, err)
+			request.release(ctx)
+		}
+	}
+
+	return result, nil
+}
+
+// newIdempotentRequest returns nil if the client did not send an idempotency key
+func newIdempotentRequest(store db.Store, email string, key string, operation string, payload any) (*idempotentRequest, *dto.ResponseError) {
+	if key == "" {
+		return nil, nil
+	}
+
+	payloadJson, err := json.Marshal(payload)
+
+	if err != nil {
+		return nil, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	hash := sha256.Sum256(append([]byte(operation+"\n"), payloadJson...))
+
+	return &idempotentRequest{
+		store:       store,
+		email:       email,
+		key:         key,
+		requestHash: hex.EncodeToString(hash[:]),
+	}, nil
+}
+
+// begin reserves the idempotency key. If a request with the same key and payload has already been completed,
+// its result is decoded into result and replayed is true.
+func (r *idempotentRequest) begin(ctx context.Context, result any) (replayed bool, respErr *dto.ResponseError) {
+	err := r.store.DeleteExpiredIdempotencyKey(ctx, &db.DeleteExpiredIdempotencyKeyParams{
+		Email:          r.email,
+		IdempotencyKey: r.key,
+	})
+
+	if err != nil {
+		return false, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	now := time.Now()
+	lockedUntil := now.Add(idempotencyKeyLease)
+
+	_, err = r.store.CreateIdempotencyKey(ctx, &db.CreateIdempotencyKeyParams{
+		Email:          r.email,
+		IdempotencyKey: r.key,
+		RequestHash:    r.requestHash,
+		ExpiresAt:      now.Add(idempotencyKeyRetention),
+		LockedUntil:    &lockedUntil,
+	})
+
+	if err == nil {
+		return false, nil
+	}
+
+	// the key already exists and its lease is not over if nothing was inserted or taken over
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return false, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	storedKey, err := r.store.GetIdempotencyKey(ctx, &db.GetIdempotencyKeyParams{
+		Email:          r.email,
+		IdempotencyKey: r.key,
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, &dto.ResponseError{
+				Message: "A request with this idempotency key is still in progress",
+				Status:  http.StatusConflict,
+				Reason:  dto.ReasonIdempotencyKeyInProgress,
+			}
+		}
+
+		return false, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	if storedKey.RequestHash != r.requestHash {
+		return false, &dto.ResponseError{
+			Message: "The idempotency key was already used for a different request",
+			Status:  http.StatusUnprocessableEntity,
+			Reason:  dto.ReasonIdempotencyKeyReused,
+		}
+	}
+
+	if storedKey.ResponseBody == nil {
+		return false, &dto.ResponseError{
+			Message: "A request with this idempotency key is still in progress",
+			Status:  http.StatusConflict,
+			Reason:  dto.ReasonIdempotencyKeyInProgress,
+		}
+	}
+
+	err = json.Unmarshal(storedKey.ResponseBody, result)
+
+	if err != nil {
+		return false, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	return true, nil
+}
+
+// complete stores the result of the request for replays, q is the transaction of the request
+func (r *idempotentRequest) complete(ctx context.Context, q db.Querier, result any) error {
+	responseBody, err := json.Marshal(result)
+
+	if err != nil {
+		return err
+	}
+
+	return q.CompleteIdempotencyKey(ctx, &db.CompleteIdempotencyKeyParams{
+		Email:          r.email,
+		IdempotencyKey: r.key,
+		ResponseBody:   responseBody,
+	})
+}
+
+// release frees the key of a failed request so that the client can retry it. It is not cancelled with the request,
+// e.g. if the client disconnected, otherwise retries would be rejected as in progress until the lease is over
+func (r *idempotentRequest) release(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), idempotencyKeyReleaseTimeout)
+	defer cancel()
+
+	err := r.store.DeleteIdempotencyKey(ctx, &db.DeleteIdempotencyKeyParams{
+		Email:          r.email,
+		IdempotencyKey: r.key,
+	})
+
+	if err != nil {
+		log.Printf("cannot release idempotency key %q: %v", r.key, err)
+	}
+}
@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+	db "kara-bank/db/repositories"
+	"kara-bank/dto"
+	"time"
+)
+
+type LedgerServiceInterface interface {
+	CreateLedgerAccount(ctx context.Context, arg *dto.CreateLedgerAccountDto) (*db.LedgerAccount, *dto.ResponseError)
+
+	ListLedgerAccounts(ctx context.Context) ([]*db.LedgerAccount, *dto.ResponseError)
+
+	PostJournal(ctx context.Context, arg *dto.PostJournalDto) (*db.JournalTxResult, *dto.ResponseError)
+
+	GetJournal(ctx context.Context, id int64) (*db.JournalTxResult, *dto.ResponseError)
+
+	GetTrialBalance(ctx context.Context, endTime *time.Time) ([]*db.GetTrialBalanceRow, *dto.ResponseError)
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"errors"
+	db "kara-bank/db/repositories"
+	"kara-bank/dto"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type LedgerServiceImpl struct {
+	store db.Store
+}
+
+func NewLedgerService(store db.Store) *LedgerServiceImpl {
+	return &LedgerServiceImpl{
+		store: store,
+	}
+}
+
+// CreateLedgerAccount adds an account to the chart of accounts
+func (l *LedgerServiceImpl) CreateLedgerAccount(ctx context.Context, arg *dto.CreateLedgerAccountDto) (*db.LedgerAccount, *dto.ResponseError) {
+	ledgerAccount, err := l.store.CreateLedgerAccount(ctx, &db.CreateLedgerAccountParams{
+		Code: arg.Code,
+		Name: arg.Name,
+		Type: arg.Type,
+	})
+
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation {
+			return nil, &dto.ResponseError{
+				Message: err.Error(),
+				Status:  http.StatusConflict,
+			}
+		}
+
+		return nil, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	return ledgerAccount, nil
+}
+
+func (l *LedgerServiceImpl) ListLedgerAccounts(ctx context.Context) ([]*db.LedgerAccount, *dto.ResponseError) {
+	ledgerAccounts, err := l.store.ListLedgerAccounts(ctx)
+
+	if err != nil {
+		return nil, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	return ledgerAccounts, nil
+}
+
+// PostJournal books a manual journal, e.g. fees or interest. Postings on the customer deposits ledger account change the
+// balance of the customer account
+func (l *LedgerServiceImpl) PostJournal(ctx context.Context, arg *dto.PostJournalDto) (*db.JournalTxResult, *dto.ResponseError) {
+	params := db.PostJournalTxParams{
+		Description: arg.Description,
+		Category:    arg.Category,
+	}
+
+	for _, posting := range arg.Postings {
+		params.Postings = append(params.Postings, db.JournalPostingParams{
+			LedgerAccount: posting.LedgerAccount,
+			AccountID:     posting.AccountId,
+			Amount:        posting.Amount,
+			Currency:      posting.Currency,
+		})
+	}
+
+	result, err := l.store.PostJournalTx(ctx, params)
+
+	if err != nil {
+		return nil, ledgerError(err)
+	}
+
+	return &result, nil
+}
+
+// GetJournal returns the journal with its postings
+func (l *LedgerServiceImpl) GetJournal(ctx context.Context, id int64) (*db.JournalTxResult, *dto.ResponseError) {
+	journal, err := l.store.GetJournal(ctx, id)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &dto.ResponseError{
+				Message: err.Error(),
+				Status:  http.StatusNotFound,
+			}
+		}
+
+		return nil, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	postings, err := l.store.ListPostingsByJournal(ctx, journal.ID)
+
+	if err != nil {
+		return nil, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	return &db.JournalTxResult{
+		Journal:  journal,
+		Postings: postings,
+	}, nil
+}
+
+// GetTrialBalance sums the debits and credits per ledger account and currency of all postings before the end time,
+// or of all postings if no end time is given
+func (l *LedgerServiceImpl) GetTrialBalance(ctx context.Context, endTime *time.Time) ([]*db.GetTrialBalanceRow, *dto.ResponseError) {
+	trialBalance, err := l.store.GetTrialBalance(ctx, endTime)
+
+	if err != nil {
+		return nil, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	return trialBalance, nil
+}
+
+// ledgerError maps the errors of posting a journal to responses
+func ledgerError(err error) *dto.ResponseError {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &dto.ResponseError{
+			Message: "account not found",
+			Status:  http.StatusNotFound,
+		}
+	}
+
+	if errors.Is(err, db.ErrUnbalancedJournal) {
+		return &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusUnprocessableEntity,
+			Reason:  dto.ReasonUnbalancedJournal,
+		}
+	}
+
+	if errors.Is(err, db.ErrInvalidPosting) {
+		return &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusUnprocessableEntity,
+			Reason:  dto.ReasonInvalidPosting,
+		}
+	}
+
+	if db.ErrorCode(err) == db.ForeignKeyViolation {
+		return &dto.ResponseError{
+			Message: "ledger account not found",
+			Status:  http.StatusUnprocessableEntity,
+			Reason:  dto.ReasonLedgerAccountNotFound,
+		}
+	}
+
+	return transferError(err)
+}
+
+var _ LedgerServiceInterface = (*LedgerServiceImpl)(nil)
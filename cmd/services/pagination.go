@@ -0,0 +1,41 @@
+package services
+
+import (
+	"kara-bank/dto"
+	"kara-bank/utils"
+	"net/http"
+)
+
+// decodeCursor returns the id behind which the requested page starts
+func decodeCursor(cursor string) (int64, *dto.ResponseError) {
+	afterId, err := utils.DecodeCursor(cursor)
+
+	if err != nil {
+		return 0, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusBadRequest,
+		}
+	}
+
+	return afterId, nil
+}
+
+// newPage builds the response envelope of a list query. The queries fetch one item more than the limit,
+// that item is dropped and only signals that there is another page.
+func newPage[T any](items []T, limit int32, id func(T) int64) *dto.PageDto[T] {
+	page := &dto.PageDto[T]{
+		Items: items,
+	}
+
+	if page.Items == nil {
+		page.Items = []T{}
+	}
+
+	if len(items) > int(limit) {
+		page.Items = items[:limit]
+		page.HasMore = true
+		page.NextCursor = utils.EncodeCursor(id(page.Items[limit-1]))
+	}
+
+	return page
+}
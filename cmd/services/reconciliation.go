@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	db "kara-bank/db/repositories"
+	"time"
+)
+
+// ReconciliationReport lists the inconsistencies between the account balances, the entries and the general ledger
+type ReconciliationReport struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	// true if none of the checks found an inconsistency
+	Consistent bool `json:"consistent"`
+	// accounts whose balance differs from the sum of their entries or from their postings on the customer deposits
+	BalanceDrifts []*db.ListBalanceDriftsRow `json:"balance_drifts"`
+	// transfers without exactly one entry that debits the amount and one entry that credits the converted amount
+	UnbalancedTransfers []*db.ListUnbalancedTransfersRow `json:"unbalanced_transfers"`
+	// entries without a transfer and journal, or with a transfer of other accounts
+	OrphanEntries []*db.Entry `json:"orphan_entries"`
+}
+
+// Reconcile checks that the account balances are explained by their entries and by the general ledger. Every check is
+// a single query and sees a consistent snapshot of the database, so that concurrent transfers are not reported.
+func (l *LedgerServiceImpl) Reconcile(ctx context.Context, now time.Time) (*ReconciliationReport, error) {
+	balanceDrifts, err := l.store.ListBalanceDrifts(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	unbalancedTransfers, err := l.store.ListUnbalancedTransfers(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	orphanEntries, err := l.store.ListOrphanEntries(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// empty lists instead of null keep the report easy to process
+	report := &ReconciliationReport{
+		GeneratedAt:         now,
+		BalanceDrifts:       append([]*db.ListBalanceDriftsRow{}, balanceDrifts...),
+		UnbalancedTransfers: append([]*db.ListUnbalancedTransfersRow{}, unbalancedTransfers...),
+		OrphanEntries:       append([]*db.Entry{}, orphanEntries...),
+	}
+
+	report.Consistent = len(balanceDrifts) == 0 && len(unbalancedTransfers) == 0 && len(orphanEntries) == 0
+
+	return report, nil
+}
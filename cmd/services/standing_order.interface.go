@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+	db "kara-bank/db/repositories"
+	"kara-bank/dto"
+)
+
+type StandingOrderServiceInterface interface {
+	CreateStandingOrder(ctx context.Context, args *dto.CreateStandingOrderDto) (*db.StandingOrder, *dto.ResponseError)
+
+	GetStandingOrder(ctx context.Context, id int64, email string, role string) (*db.StandingOrder, *dto.ResponseError)
+
+	ListStandingOrders(ctx context.Context, args *dto.ListStandingOrdersDto, email string) (*dto.PageDto[*db.StandingOrder], *dto.ResponseError)
+
+	UpdateStandingOrder(ctx context.Context, args *dto.UpdateStandingOrderDto) (*db.StandingOrder, *dto.ResponseError)
+
+	CancelStandingOrder(ctx context.Context, id int64, email string) (*db.StandingOrder, *dto.ResponseError)
+}
+
+// standingOrderTransfers is the part of the transfer service that executes standing orders
+type standingOrderTransfers interface {
+	validAccounts(ctx context.Context, fromUser string, fromAccountId int64, toAccountId int64) (*db.Account, *db.Account, *dto.ResponseError)
+
+	transfer(
+		ctx context.Context,
+		fromAccount *db.Account,
+		toAccount *db.Account,
+		amount int64,
+		afterTransfer func(q *db.Queries, result db.TransferTxResult) error,
+	) (*db.TransferTxResult, *dto.ResponseError)
+
+	needsApproval(amount int64) bool
+}
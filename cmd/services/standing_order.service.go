@@ -0,0 +1,412 @@
+package services
+
+import (
+	"context"
+	"errors"
+	db "kara-bank/db/repositories"
+	"kara-bank/dto"
+	"kara-bank/utils"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// states of a standing order
+const (
+	standingOrderActive    = "active"
+	standingOrderCompleted = "completed"
+	standingOrderFailed    = "failed"
+	standingOrderCancelled = "cancelled"
+)
+
+const (
+	// standingOrderBatchSize is the maximum number of standing orders executed per run of the scheduler
+	standingOrderBatchSize = 100
+	// standingOrderMaxAttempts is how often an execution is tried before it is skipped
+	standingOrderMaxAttempts = 3
+	standingOrderRetryDelay  = time.Hour
+)
+
+type StandingOrderServiceImpl struct {
+	store db.Store
+	// executes the transfers of the standing orders
+	transferService standingOrderTransfers
+}
+
+func NewStandingOrderService(store db.Store, transferService standingOrderTransfers) *StandingOrderServiceImpl {
+	return &StandingOrderServiceImpl{
+		store:           store,
+		transferService: transferService,
+	}
+}
+
+func (s *StandingOrderServiceImpl) CreateStandingOrder(ctx context.Context, arg *dto.CreateStandingOrderDto) (*db.StandingOrder, *dto.ResponseError) {
+	startAt := time.Now()
+
+	if arg.StartAt != nil {
+		if arg.StartAt.Before(startAt) {
+			return nil, &dto.ResponseError{
+				Message: "start time must not be in the past",
+				Status:  http.StatusBadRequest,
+			}
+		}
+
+		startAt = *arg.StartAt
+	}
+
+	if arg.EndAt != nil && arg.EndAt.Before(startAt) {
+		return nil, &dto.ResponseError{
+			Message: "end time must not be before start time",
+			Status:  http.StatusBadRequest,
+		}
+	}
+
+	respErr := s.checkApprovalThreshold(arg.Amount)
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	_, _, respErr = s.transferService.validAccounts(ctx, arg.Owner, arg.FromAccountId, arg.ToAccountId)
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	params := &db.CreateStandingOrderParams{
+		FromAccountID:   arg.FromAccountId,
+		ToAccountID:     arg.ToAccountId,
+		Amount:          arg.Amount,
+		Frequency:       arg.Frequency,
+		StartAt:         startAt,
+		EndAt:           arg.EndAt,
+		NextExecutionAt: &startAt,
+	}
+
+	standingOrder, err := s.store.CreateStandingOrder(ctx, params)
+
+	if err != nil {
+		return nil, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	return standingOrder, nil
+}
+
+func (s *StandingOrderServiceImpl) GetStandingOrder(ctx context.Context, id int64, email string, role string) (*db.StandingOrder, *dto.ResponseError) {
+	standingOrder, respErr := s.getStandingOrder(ctx, id)
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	if role == utils.BankerRole || role == utils.AdminRole {
+		return standingOrder, nil
+	}
+
+	respErr = s.checkOwner(ctx, standingOrder, email)
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	return standingOrder, nil
+}
+
+func (s *StandingOrderServiceImpl) ListStandingOrders(ctx context.Context, arg *dto.ListStandingOrdersDto, email string) (*dto.PageDto[*db.StandingOrder], *dto.ResponseError) {
+	afterId, respErr := decodeCursor(arg.Cursor)
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	params := &db.ListStandingOrdersByOwnerParams{
+		Owner:   email,
+		AfterID: afterId,
+		Limit:   arg.Limit + 1,
+	}
+
+	standingOrderList, err := s.store.ListStandingOrdersByOwner(ctx, params)
+
+	if err != nil {
+		return nil, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	return newPage(standingOrderList, arg.Limit, func(standingOrder *db.StandingOrder) int64 { return standingOrder.ID }), nil
+}
+
+func (s *StandingOrderServiceImpl) UpdateStandingOrder(ctx context.Context, arg *dto.UpdateStandingOrderDto) (*db.StandingOrder, *dto.ResponseError) {
+	standingOrder, respErr := s.activeStandingOrder(ctx, arg.Id, arg.Owner)
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	respErr = s.checkApprovalThreshold(arg.Amount)
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	if arg.EndAt != nil && arg.EndAt.Before(standingOrder.StartAt) {
+		return nil, &dto.ResponseError{
+			Message: "end time must not be before start time",
+			Status:  http.StatusBadRequest,
+		}
+	}
+
+	params := &db.UpdateStandingOrderParams{
+		Amount:                  arg.Amount,
+		EndAt:                   arg.EndAt,
+		Status:                  standingOrder.Status,
+		NextExecutionAt:         standingOrder.NextExecutionAt,
+		ID:                      standingOrder.ID,
+		ExpectedNextExecutionAt: standingOrder.NextExecutionAt,
+	}
+
+	// the order is completed if the new end time is before the pending execution
+	scheduledAt, _ := utils.ScheduledExecution(standingOrder.StartAt, standingOrder.Frequency, standingOrder.ExecutionCount)
+
+	if arg.EndAt != nil && scheduledAt.After(*arg.EndAt) {
+		params.Status = standingOrderCompleted
+		params.NextExecutionAt = nil
+	}
+
+	return s.updateStandingOrder(ctx, params)
+}
+
+func (s *StandingOrderServiceImpl) CancelStandingOrder(ctx context.Context, id int64, email string) (*db.StandingOrder, *dto.ResponseError) {
+	standingOrder, respErr := s.activeStandingOrder(ctx, id, email)
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	params := &db.UpdateStandingOrderParams{
+		Amount:                  standingOrder.Amount,
+		EndAt:                   standingOrder.EndAt,
+		Status:                  standingOrderCancelled,
+		NextExecutionAt:         nil,
+		ID:                      standingOrder.ID,
+		ExpectedNextExecutionAt: standingOrder.NextExecutionAt,
+	}
+
+	return s.updateStandingOrder(ctx, params)
+}
+
+// ExecuteDueStandingOrders executes a batch of standing orders that are due at the given time and returns
+// the number of processed orders. Failed executions are retried later and recorded in the standing order.
+// The executions are guarded by the scheduled time, so that concurrent schedulers execute every order only once.
+func (s *StandingOrderServiceImpl) ExecuteDueStandingOrders(ctx context.Context, now time.Time) (int, error) {
+	standingOrders, err := s.store.ListDueStandingOrders(ctx, &db.ListDueStandingOrdersParams{
+		Now:   now,
+		Limit: standingOrderBatchSize,
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+
+	for _, standingOrder := range standingOrders {
+		err = s.executeStandingOrder(ctx, standingOrder, now)
+
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return len(standingOrders), errors.Join(errs...)
+}
+
+func (s *StandingOrderServiceImpl) executeStandingOrder(ctx context.Context, standingOrder *db.StandingOrder, now time.Time) error {
+	fromAccount, err := s.store.GetAccount(ctx, standingOrder.FromAccountID)
+
+	if err != nil {
+		return s.recordFailure(ctx, standingOrder, now, err.Error())
+	}
+
+	toAccount, err := s.store.GetAccount(ctx, standingOrder.ToAccountID)
+
+	if err != nil {
+		return s.recordFailure(ctx, standingOrder, now, err.Error())
+	}
+
+	// the standing order is updated within the transfer transaction. If another scheduler executed it in the
+	// meantime the update finds no row and the transfer is rolled back
+	_, respErr := s.transferService.transfer(ctx, fromAccount, toAccount, standingOrder.Amount, func(q *db.Queries, result db.TransferTxResult) error {
+		_, err := q.UpdateStandingOrderExecution(ctx, executionDone(standingOrder, &result.Transfer.ID, nil))
+		return err
+	})
+
+	if respErr != nil {
+		return s.recordFailure(ctx, standingOrder, now, respErr.Message)
+	}
+
+	return nil
+}
+
+// recordFailure schedules a retry of the failed execution or skips it after too many attempts
+func (s *StandingOrderServiceImpl) recordFailure(ctx context.Context, standingOrder *db.StandingOrder, now time.Time, message string) error {
+	params := executionDone(standingOrder, nil, &message)
+
+	if standingOrder.FailedAttempts+1 < standingOrderMaxAttempts {
+		retryAt := now.Add(standingOrderRetryDelay)
+
+		params = &db.UpdateStandingOrderExecutionParams{
+			Status:                  standingOrderActive,
+			NextExecutionAt:         &retryAt,
+			ExecutionCount:          standingOrder.ExecutionCount,
+			FailedAttempts:          standingOrder.FailedAttempts + 1,
+			LastError:               &message,
+			ID:                      standingOrder.ID,
+			ExpectedNextExecutionAt: *standingOrder.NextExecutionAt,
+		}
+	}
+
+	_, err := s.store.UpdateStandingOrderExecution(ctx, params)
+
+	// nothing to record if the order was executed, changed or cancelled in the meantime
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+
+	return nil
+}
+
+// executionDone returns the update of a standing order whose pending execution is done, either with a transfer
+// or skipped after too many failed attempts. The order is completed after its last execution, or failed if the
+// last execution was skipped.
+func executionDone(standingOrder *db.StandingOrder, transferId *int64, lastError *string) *db.UpdateStandingOrderExecutionParams {
+	params := &db.UpdateStandingOrderExecutionParams{
+		Status:                  standingOrderActive,
+		ExecutionCount:          standingOrder.ExecutionCount + 1,
+		FailedAttempts:          0,
+		LastError:               lastError,
+		LastTransferID:          transferId,
+		ID:                      standingOrder.ID,
+		ExpectedNextExecutionAt: *standingOrder.NextExecutionAt,
+	}
+
+	nextExecutionAt, ok := utils.ScheduledExecution(standingOrder.StartAt, standingOrder.Frequency, params.ExecutionCount)
+
+	if ok && (standingOrder.EndAt == nil || !nextExecutionAt.After(*standingOrder.EndAt)) {
+		params.NextExecutionAt = &nextExecutionAt
+	} else if lastError == nil {
+		params.Status = standingOrderCompleted
+	} else {
+		params.Status = standingOrderFailed
+	}
+
+	return params
+}
+
+func (s *StandingOrderServiceImpl) getStandingOrder(ctx context.Context, id int64) (*db.StandingOrder, *dto.ResponseError) {
+	standingOrder, err := s.store.GetStandingOrder(ctx, id)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &dto.ResponseError{
+				Message: err.Error(),
+				Status:  http.StatusNotFound,
+			}
+		}
+
+		return nil, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	return standingOrder, nil
+}
+
+// activeStandingOrder returns the standing order if it belongs to the user and can still be changed
+func (s *StandingOrderServiceImpl) activeStandingOrder(ctx context.Context, id int64, email string) (*db.StandingOrder, *dto.ResponseError) {
+	standingOrder, respErr := s.getStandingOrder(ctx, id)
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	respErr = s.checkOwner(ctx, standingOrder, email)
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	if standingOrder.Status != standingOrderActive {
+		return nil, &dto.ResponseError{
+			Message: "Only active standing orders can be changed",
+			Status:  http.StatusConflict,
+			Reason:  dto.ReasonStandingOrderNotActive,
+		}
+	}
+
+	return standingOrder, nil
+}
+
+// checkApprovalThreshold rejects amounts that need the approval of a banker, because standing orders are executed unattended
+func (s *StandingOrderServiceImpl) checkApprovalThreshold(amount int64) *dto.ResponseError {
+	if s.transferService.needsApproval(amount) {
+		return &dto.ResponseError{
+			Message: "Standing orders cannot exceed the amount above which transfers need an approval",
+			Status:  http.StatusUnprocessableEntity,
+			Reason:  dto.ReasonApprovalRequired,
+		}
+	}
+
+	return nil
+}
+
+// checkOwner verifies that the standing order sends money from an account of the user
+func (s *StandingOrderServiceImpl) checkOwner(ctx context.Context, standingOrder *db.StandingOrder, email string) *dto.ResponseError {
+	account, err := s.store.GetAccount(ctx, standingOrder.FromAccountID)
+
+	if err != nil {
+		return &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	if account.Owner != email {
+		return &dto.ResponseError{
+			Message: "You have no permission for this standing order",
+			Status:  http.StatusUnauthorized,
+		}
+	}
+
+	return nil
+}
+
+func (s *StandingOrderServiceImpl) updateStandingOrder(ctx context.Context, params *db.UpdateStandingOrderParams) (*db.StandingOrder, *dto.ResponseError) {
+	standingOrder, err := s.store.UpdateStandingOrder(ctx, params)
+
+	if err != nil {
+		// the scheduler executed the order while it was changed
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &dto.ResponseError{
+				Message: "The standing order was changed in the meantime, please try again",
+				Status:  http.StatusConflict,
+				Reason:  dto.ReasonConcurrentUpdate,
+			}
+		}
+
+		return nil, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	return standingOrder, nil
+}
+
+var _ StandingOrderServiceInterface = (*StandingOrderServiceImpl)(nil)
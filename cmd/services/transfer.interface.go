@@ -6,6 +6,26 @@ import (
 	"kara-bank/dto"
 )
 
+// CreateTransferResult contains the executed transfer, or the pending approval if the amount needs to be approved by a banker
+type CreateTransferResult struct {
+	*db.TransferTxResult
+	Approval *db.TransferApproval `json:"approval,omitempty"`
+}
+
 type TransferServiceInterface interface {
-	CreateTransfer(ctx context.Context, arg *dto.CreateTransferDto) (*db.TransferTxResult, *dto.ResponseError)
+	CreateTransfer(ctx context.Context, arg *dto.CreateTransferDto) (*CreateTransferResult, *dto.ResponseError)
+
+	ReverseTransfer(ctx context.Context, arg *dto.ReverseTransferDto) (*db.TransferTxResult, *dto.ResponseError)
+
+	GetTransfer(ctx context.Context, id int64, email string, role string) (*db.Transfer, *dto.ResponseError)
+
+	ListTransfers(ctx context.Context, arg *dto.ListTransfersDto, email string, role string) (*dto.PageDto[*db.Transfer], *dto.ResponseError)
+
+	GetTransferApproval(ctx context.Context, id int64, email string, role string) (*db.TransferApproval, *dto.ResponseError)
+
+	ListPendingTransferApprovals(ctx context.Context, arg *dto.ListTransferApprovalsDto) (*dto.PageDto[*db.TransferApproval], *dto.ResponseError)
+
+	ApproveTransfer(ctx context.Context, id int64, reviewer string) (*db.ApproveTransferTxResult, *dto.ResponseError)
+
+	RejectTransfer(ctx context.Context, id int64, reviewer string) (*db.TransferApproval, *dto.ResponseError)
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	db "kara-bank/db/repositories"
 	"kara-bank/dto"
+	"kara-bank/utils"
 	"net/http"
 
 	"github.com/jackc/pgx/v5"
@@ -12,80 +13,348 @@ import (
 
 type TransferServiceImpl struct {
 	store db.Store
+	// converts amounts between currencies, transfers between different currencies are rejected if not set
+	exchangeRates utils.ExchangeRateProvider
+	// transfers with a larger amount must be approved by a banker, disabled if zero
+	approvalThreshold int64
 }
 
-func NewTransferService(store db.Store) *TransferServiceImpl {
+func NewTransferService(store db.Store, exchangeRates utils.ExchangeRateProvider, approvalThreshold int64) *TransferServiceImpl {
 	return &TransferServiceImpl{
-		store: store,
+		store:             store,
+		exchangeRates:     exchangeRates,
+		approvalThreshold: approvalThreshold,
 	}
 }
 
-func (t *TransferServiceImpl) CreateTransfer(ctx context.Context, arg *dto.CreateTransferDto) (*db.TransferTxResult, *dto.ResponseError) {
-	respErr := t.validAccounts(ctx, arg.FromUser, arg.FromAccountId, arg.ToAccountId)
+func (t *TransferServiceImpl) CreateTransfer(ctx context.Context, arg *dto.CreateTransferDto) (*CreateTransferResult, *dto.ResponseError) {
+	return runIdempotent(ctx, t.store, arg.FromUser, arg.IdempotencyKey, "CreateTransfer", arg, func(complete func(q *db.Queries, result *CreateTransferResult) error) (*CreateTransferResult, *dto.ResponseError) {
+		return t.createTransfer(ctx, arg, complete)
+	})
+}
+
+// createTransfer executes the transfer or creates an approval for it. afterCreate receives the result within the
+// database transaction of the transfer or the approval
+func (t *TransferServiceImpl) createTransfer(ctx context.Context, arg *dto.CreateTransferDto, afterCreate func(q *db.Queries, result *CreateTransferResult) error) (*CreateTransferResult, *dto.ResponseError) {
+	fromAccount, toAccount, respErr := t.validAccounts(ctx, arg.FromUser, arg.FromAccountId, arg.ToAccountId)
 
 	if respErr != nil {
 		return nil, respErr
 	}
 
+	if t.needsApproval(arg.Amount) {
+		approval, respErr := t.createTransferApproval(ctx, fromAccount, toAccount, arg, func(q *db.Queries, approval *db.TransferApproval) error {
+			return afterCreate(q, &CreateTransferResult{Approval: approval})
+		})
+
+		if respErr != nil {
+			return nil, respErr
+		}
+
+		return &CreateTransferResult{Approval: approval}, nil
+	}
+
+	result, respErr := t.transfer(ctx, fromAccount, toAccount, arg.Amount, func(q *db.Queries, result db.TransferTxResult) error {
+		return afterCreate(q, &CreateTransferResult{TransferTxResult: &result})
+	})
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	return &CreateTransferResult{TransferTxResult: result}, nil
+}
+
+// transfer moves the amount from one account to the other and converts it if the currencies differ.
+// afterTransfer is optional and runs within the database transaction of the transfer.
+func (t *TransferServiceImpl) transfer(
+	ctx context.Context,
+	fromAccount *db.Account,
+	toAccount *db.Account,
+	amount int64,
+	afterTransfer func(q *db.Queries, result db.TransferTxResult) error,
+) (*db.TransferTxResult, *dto.ResponseError) {
 	queryParam := db.TransferTxParams{
-		FromAccountID: arg.FromAccountId,
-		ToAccountID:   arg.ToAccountId,
-		Amount:        arg.Amount,
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        amount,
+		AfterTransfer: afterTransfer,
+	}
+
+	if fromAccount.Currency != toAccount.Currency {
+		respErr := t.convertAmount(ctx, &queryParam, fromAccount.Currency, toAccount.Currency)
+
+		if respErr != nil {
+			return nil, respErr
+		}
 	}
 
 	transfer, err := t.store.TransferTx(ctx, queryParam)
 
 	if err != nil {
+		return nil, transferError(err)
+	}
+
+	return &transfer, nil
+}
+
+// transferError maps the errors of executing a transfer to responses. Other check violations than the balance
+// constraint are not caused by the funds of the sender and stay internal errors
+func transferError(err error) *dto.ResponseError {
+	if errors.Is(err, db.ErrInsufficientFunds) || db.ConstraintName(err) == db.BalanceOverdraftConstraint {
+		return &dto.ResponseError{
+			Message: db.ErrInsufficientFunds.Error(),
+			Status:  http.StatusUnprocessableEntity,
+			Reason:  dto.ReasonInsufficientFunds,
+		}
+	}
+
+	if errors.Is(err, db.ErrCurrencyMismatch) {
+		return &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusUnprocessableEntity,
+			Reason:  dto.ReasonCurrencyMismatch,
+		}
+	}
+
+	return &dto.ResponseError{
+		Message: err.Error(),
+		Status:  http.StatusInternalServerError,
+	}
+}
+
+// ReverseTransfer sends the amount of a transfer back to the sender, fully or partially
+func (t *TransferServiceImpl) ReverseTransfer(ctx context.Context, arg *dto.ReverseTransferDto) (*db.TransferTxResult, *dto.ResponseError) {
+	result, err := t.store.ReverseTransferTx(ctx, db.ReverseTransferTxParams{
+		TransferID: arg.TransferId,
+		Amount:     arg.Amount,
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &dto.ResponseError{
+				Message: err.Error(),
+				Status:  http.StatusNotFound,
+			}
+		}
+
+		if errors.Is(err, db.ErrTransferAlreadyReversed) {
+			return nil, &dto.ResponseError{
+				Message: err.Error(),
+				Status:  http.StatusConflict,
+				Reason:  dto.ReasonTransferAlreadyReversed,
+			}
+		}
+
+		if errors.Is(err, db.ErrReversalOfReversal) || errors.Is(err, db.ErrInvalidReversalAmount) {
+			return nil, &dto.ResponseError{
+				Message: err.Error(),
+				Status:  http.StatusUnprocessableEntity,
+				Reason:  dto.ReasonInvalidReversal,
+			}
+		}
+
+		return nil, transferError(err)
+	}
+
+	return &result, nil
+}
+
+func (t *TransferServiceImpl) GetTransfer(ctx context.Context, id int64, email string, role string) (*db.Transfer, *dto.ResponseError) {
+	transfer, err := t.store.GetTransfer(ctx, id)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &dto.ResponseError{
+				Message: err.Error(),
+				Status:  http.StatusNotFound,
+			}
+		}
+
 		return nil, &dto.ResponseError{
 			Message: err.Error(),
 			Status:  http.StatusInternalServerError,
 		}
 	}
 
-	return &transfer, nil
+	if role == utils.BankerRole || role == utils.AdminRole {
+		return transfer, nil
+	}
+
+	// customers can see transfers they sent or received
+	for _, accountId := range []int64{transfer.FromAccountID, transfer.ToAccountID} {
+		account, err := t.store.GetAccount(ctx, accountId)
+
+		if err != nil {
+			return nil, &dto.ResponseError{
+				Message: err.Error(),
+				Status:  http.StatusInternalServerError,
+			}
+		}
+
+		if account.Owner == email {
+			return transfer, nil
+		}
+	}
+
+	return nil, &dto.ResponseError{
+		Message: "You have no permission for this transfer",
+		Status:  http.StatusUnauthorized,
+	}
 }
 
-func (t *TransferServiceImpl) validAccounts(ctx context.Context, fromUser string, fromAccountId int64, toAccountId int64) *dto.ResponseError {
-	fromAccount, err := t.store.GetAccount(ctx, fromAccountId)
+func (t *TransferServiceImpl) ListTransfers(ctx context.Context, arg *dto.ListTransfersDto, email string, role string) (*dto.PageDto[*db.Transfer], *dto.ResponseError) {
+	respErr := validTimeRange(arg.StartTime, arg.EndTime)
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	afterId, respErr := decodeCursor(arg.Cursor)
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	account, err := t.store.GetAccount(ctx, arg.AccountId)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &dto.ResponseError{
+				Message: err.Error(),
+				Status:  http.StatusNotFound,
+			}
+		}
+
+		return nil, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	if account.Owner != email && role != utils.BankerRole && role != utils.AdminRole {
+		return nil, &dto.ResponseError{
+			Message: "You have no permission for this account",
+			Status:  http.StatusUnauthorized,
+		}
+	}
+
+	outgoing, incoming := directionFilter(arg.Direction)
+
+	params := &db.ListTransfersParams{
+		AccountID: account.ID,
+		Outgoing:  outgoing,
+		Incoming:  incoming,
+		StartTime: arg.StartTime,
+		EndTime:   arg.EndTime,
+		AfterID:   afterId,
+		Limit:     arg.Limit + 1,
+	}
+
+	transferList, err := t.store.ListTransfers(ctx, params)
+
+	if err != nil {
+		return nil, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	return newPage(transferList, arg.Limit, func(transfer *db.Transfer) int64 { return transfer.ID }), nil
+}
+
+// convertAmount sets the amount that is credited to the receiving account in its currency, rounded half up
+func (t *TransferServiceImpl) convertAmount(ctx context.Context, arg *db.TransferTxParams, fromCurrency string, toCurrency string) *dto.ResponseError {
+	if t.exchangeRates == nil {
+		return &dto.ResponseError{
+			Message: "Transfers between accounts with different currencies are not supported",
+			Status:  http.StatusUnprocessableEntity,
+			Reason:  dto.ReasonCurrencyMismatch,
+		}
+	}
+
+	rate, err := t.exchangeRates.GetRate(ctx, fromCurrency, toCurrency)
+
+	if err != nil {
+		if errors.Is(err, utils.ErrExchangeRateNotFound) {
 			return &dto.ResponseError{
+				Message: err.Error(),
+				Status:  http.StatusUnprocessableEntity,
+				Reason:  dto.ReasonExchangeRateNotFound,
+			}
+		}
+
+		return &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	toAmount, err := rate.Convert(arg.Amount)
+
+	if err != nil {
+		return &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusUnprocessableEntity,
+			Reason:  dto.ReasonAmountTooLarge,
+		}
+	}
+
+	arg.ToAmount = toAmount
+	arg.ExchangeRate = rate
+
+	if arg.ToAmount <= 0 {
+		return &dto.ResponseError{
+			Message: "Amount is too small to be converted",
+			Status:  http.StatusUnprocessableEntity,
+			Reason:  dto.ReasonAmountTooSmall,
+		}
+	}
+
+	return nil
+}
+
+func (t *TransferServiceImpl) validAccounts(ctx context.Context, fromUser string, fromAccountId int64, toAccountId int64) (*db.Account, *db.Account, *dto.ResponseError) {
+	fromAccount, err := t.store.GetAccount(ctx, fromAccountId)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, &dto.ResponseError{
 				Message: "fromAccount not found",
 				Status:  http.StatusNotFound,
 			}
 		}
 
-		return &dto.ResponseError{
+		return nil, nil, &dto.ResponseError{
 			Message: err.Error(),
 			Status:  http.StatusInternalServerError,
 		}
 	}
 
 	if fromAccount.Owner != fromUser {
-		return &dto.ResponseError{
+		return nil, nil, &dto.ResponseError{
 			Message: "You cannot send money from accounts other than yours",
 			Status:  http.StatusUnauthorized,
 		}
 	}
 
-	_, err = t.store.GetAccount(ctx, toAccountId)
+	toAccount, err := t.store.GetAccount(ctx, toAccountId)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return &dto.ResponseError{
+			return nil, nil, &dto.ResponseError{
 				Message: "toAccount not found",
 				Status:  http.StatusNotFound,
 			}
 		}
 
-		return &dto.ResponseError{
+		return nil, nil, &dto.ResponseError{
 			Message: err.Error(),
 			Status:  http.StatusInternalServerError,
 		}
 	}
 
-	return nil
+	return fromAccount, toAccount, nil
 }
 
 var _ TransferServiceInterface = (*TransferServiceImpl)(nil)
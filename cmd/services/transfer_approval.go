@@ -0,0 +1,204 @@
+package services
+
+import (
+	"context"
+	"errors"
+	db "kara-bank/db/repositories"
+	"kara-bank/dto"
+	"kara-bank/utils"
+	"net/http"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// needsApproval reports whether a transfer of the amount must be approved by a banker before it is executed
+func (t *TransferServiceImpl) needsApproval(amount int64) bool {
+	return t.approvalThreshold > 0 && amount > t.approvalThreshold
+}
+
+// createTransferApproval holds the amount on the sending account until a banker approves or rejects the transfer
+func (t *TransferServiceImpl) createTransferApproval(
+	ctx context.Context,
+	fromAccount *db.Account,
+	toAccount *db.Account,
+	arg *dto.CreateTransferDto,
+	afterCreate func(q *db.Queries, approval *db.TransferApproval) error,
+) (*db.TransferApproval, *dto.ResponseError) {
+	// the amount is converted when the transfer is approved, but transfers that cannot be converted are rejected right away
+	if fromAccount.Currency != toAccount.Currency {
+		respErr := t.convertAmount(ctx, &db.TransferTxParams{Amount: arg.Amount}, fromAccount.Currency, toAccount.Currency)
+
+		if respErr != nil {
+			return nil, respErr
+		}
+	}
+
+	approval, err := t.store.CreateTransferApprovalTx(ctx, db.CreateTransferApprovalTxParams{
+		CreateTransferApprovalParams: db.CreateTransferApprovalParams{
+			FromAccountID: fromAccount.ID,
+			ToAccountID:   toAccount.ID,
+			Amount:        arg.Amount,
+			Initiator:     arg.FromUser,
+		},
+		AfterCreate: afterCreate,
+	})
+
+	if err != nil {
+		return nil, transferError(err)
+	}
+
+	return approval, nil
+}
+
+func (t *TransferServiceImpl) GetTransferApproval(ctx context.Context, id int64, email string, role string) (*db.TransferApproval, *dto.ResponseError) {
+	approval, respErr := t.getTransferApproval(ctx, id)
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	if approval.Initiator != email && role != utils.BankerRole && role != utils.AdminRole {
+		return nil, &dto.ResponseError{
+			Message: "You have no permission for this transfer",
+			Status:  http.StatusUnauthorized,
+		}
+	}
+
+	return approval, nil
+}
+
+// ListPendingTransferApprovals returns the transfers that wait for the approval of a banker, oldest first
+func (t *TransferServiceImpl) ListPendingTransferApprovals(ctx context.Context, arg *dto.ListTransferApprovalsDto) (*dto.PageDto[*db.TransferApproval], *dto.ResponseError) {
+	afterId, respErr := decodeCursor(arg.Cursor)
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	approvalList, err := t.store.ListPendingTransferApprovals(ctx, &db.ListPendingTransferApprovalsParams{
+		AfterID: afterId,
+		Limit:   arg.Limit + 1,
+	})
+
+	if err != nil {
+		return nil, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	return newPage(approvalList, arg.Limit, func(approval *db.TransferApproval) int64 { return approval.ID }), nil
+}
+
+// ApproveTransfer executes a pending transfer. The amount is converted with the exchange rate at the time of the approval
+func (t *TransferServiceImpl) ApproveTransfer(ctx context.Context, id int64, reviewer string) (*db.ApproveTransferTxResult, *dto.ResponseError) {
+	approval, respErr := t.getTransferApproval(ctx, id)
+
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	params := db.DecideTransferApprovalTxParams{
+		ApprovalID: approval.ID,
+		Reviewer:   reviewer,
+	}
+
+	fromAccount, err := t.store.GetAccount(ctx, approval.FromAccountID)
+
+	if err != nil {
+		return nil, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	toAccount, err := t.store.GetAccount(ctx, approval.ToAccountID)
+
+	if err != nil {
+		return nil, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	if fromAccount.Currency != toAccount.Currency {
+		conversion := db.TransferTxParams{Amount: approval.Amount}
+		respErr = t.convertAmount(ctx, &conversion, fromAccount.Currency, toAccount.Currency)
+
+		if respErr != nil {
+			return nil, respErr
+		}
+
+		params.ToAmount = conversion.ToAmount
+		params.ExchangeRate = conversion.ExchangeRate
+	}
+
+	result, err := t.store.ApproveTransferTx(ctx, params)
+
+	if err != nil {
+		return nil, approvalError(err)
+	}
+
+	return &result, nil
+}
+
+// RejectTransfer cancels a pending transfer and releases the held amount
+func (t *TransferServiceImpl) RejectTransfer(ctx context.Context, id int64, reviewer string) (*db.TransferApproval, *dto.ResponseError) {
+	approval, err := t.store.RejectTransferTx(ctx, db.DecideTransferApprovalTxParams{
+		ApprovalID: id,
+		Reviewer:   reviewer,
+	})
+
+	if err != nil {
+		return nil, approvalError(err)
+	}
+
+	return approval, nil
+}
+
+func (t *TransferServiceImpl) getTransferApproval(ctx context.Context, id int64) (*db.TransferApproval, *dto.ResponseError) {
+	approval, err := t.store.GetTransferApproval(ctx, id)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &dto.ResponseError{
+				Message: err.Error(),
+				Status:  http.StatusNotFound,
+			}
+		}
+
+		return nil, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	return approval, nil
+}
+
+// approvalError maps the errors of deciding a transfer approval to responses
+func approvalError(err error) *dto.ResponseError {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusNotFound,
+		}
+	}
+
+	if errors.Is(err, db.ErrApprovalDecided) {
+		return &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusConflict,
+			Reason:  dto.ReasonApprovalDecided,
+		}
+	}
+
+	if errors.Is(err, db.ErrApprovalByInitiator) {
+		return &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusUnauthorized,
+		}
+	}
+
+	return transferError(err)
+}
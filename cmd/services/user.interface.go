@@ -4,6 +4,8 @@ import (
 	"context"
 	db "kara-bank/db/repositories"
 	"kara-bank/dto"
+
+	"github.com/google/uuid"
 )
 
 type UserServiceInterface interface {
@@ -11,5 +13,19 @@ type UserServiceInterface interface {
 
 	GetUser(ctx context.Context, email string) (*db.User, *dto.ResponseError)
 
-	LoginUser(ctx context.Context, arg *dto.LoginUserDto) (string, *dto.ResponseError)
+	LoginUser(ctx context.Context, arg *dto.LoginUserDto) (*dto.LoginUserResultDto, *dto.ResponseError)
+
+	RefreshAccessToken(ctx context.Context, refreshToken string) (*dto.RefreshTokenResultDto, *dto.ResponseError)
+
+	ValidateSession(ctx context.Context, sessionId uuid.UUID, email string) *dto.ResponseError
+
+	LogoutUser(ctx context.Context, sessionId uuid.UUID) *dto.ResponseError
+
+	LogoutUserEverywhere(ctx context.Context, email string) *dto.ResponseError
+
+	RevokeUserSessions(ctx context.Context, email string, role string) *dto.ResponseError
+
+	ListSessions(ctx context.Context, email string, currentSessionId uuid.UUID) ([]*dto.SessionDto, *dto.ResponseError)
+
+	RevokeSession(ctx context.Context, sessionId uuid.UUID, email string) *dto.ResponseError
 }
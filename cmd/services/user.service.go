@@ -9,10 +9,16 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	accessTokenDuration  = 30 * time.Minute
+	refreshTokenDuration = 168 * time.Hour // valid for a week
+)
+
 type UserServiceImpl struct {
 	store      db.Store
 	tokenMaker utils.TokenMaker
@@ -66,18 +72,18 @@ func (u *UserServiceImpl) GetUser(ctx context.Context, email string) (*db.User,
 	return nil, nil
 }
 
-func (u *UserServiceImpl) LoginUser(ctx context.Context, arg *dto.LoginUserDto) (string, *dto.ResponseError) {
+func (u *UserServiceImpl) LoginUser(ctx context.Context, arg *dto.LoginUserDto) (*dto.LoginUserResultDto, *dto.ResponseError) {
 	user, err := u.store.GetUser(ctx, arg.Email)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return "", &dto.ResponseError{
+			return nil, &dto.ResponseError{
 				Message: err.Error(),
 				Status:  http.StatusNotFound,
 			}
 		}
 
-		return "", &dto.ResponseError{
+		return nil, &dto.ResponseError{
 			Message: err.Error(),
 			Status:  http.StatusInternalServerError,
 		}
@@ -86,32 +92,42 @@ func (u *UserServiceImpl) LoginUser(ctx context.Context, arg *dto.LoginUserDto)
 	err = bcrypt.CompareHashAndPassword([]byte(user.HashedPassword), []byte(arg.Password))
 
 	if err != nil {
-		return "", &dto.ResponseError{
+		return nil, &dto.ResponseError{
 			Message: err.Error(),
 			Status:  http.StatusUnauthorized,
 		}
 	}
 
-	accessToken, _, err := u.tokenMaker.CreateToken(user.Email, user.UserRole, 30*time.Minute)
+	// access and refresh token both carry the session id so that revoking the session invalidates both of them
+	sessionId, err := uuid.NewRandom()
+
+	if err != nil {
+		return nil, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	accessToken, accessTokenPayload, err := u.tokenMaker.CreateToken(user.Email, user.UserRole, sessionId, utils.AccessTokenType, accessTokenDuration)
 
 	if err != nil {
-		return "", &dto.ResponseError{
+		return nil, &dto.ResponseError{
 			Message: err.Error(),
 			Status:  http.StatusInternalServerError,
 		}
 	}
 
-	refreshToken, refreshTokenPayload, err := u.tokenMaker.CreateToken(user.Email, user.UserRole, 168*time.Hour) // valid for a week
+	refreshToken, refreshTokenPayload, err := u.tokenMaker.CreateToken(user.Email, user.UserRole, sessionId, utils.RefreshTokenType, refreshTokenDuration)
 
 	if err != nil {
-		return "", &dto.ResponseError{
+		return nil, &dto.ResponseError{
 			Message: err.Error(),
 			Status:  http.StatusInternalServerError,
 		}
 	}
 
 	sessionParams := &db.CreateSessionParams{
-		ID:           refreshTokenPayload.ID,
+		ID:           sessionId,
 		Email:        refreshTokenPayload.Email,
 		RefreshToken: refreshToken,
 		UserAgent:    arg.UserAgent,
@@ -123,13 +139,235 @@ func (u *UserServiceImpl) LoginUser(ctx context.Context, arg *dto.LoginUserDto)
 	_, err = u.store.CreateSession(ctx, sessionParams)
 
 	if err != nil {
-		return "", &dto.ResponseError{
+		return nil, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	return &dto.LoginUserResultDto{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessTokenPayload.ExpiredAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: refreshTokenPayload.ExpiredAt,
+	}, nil
+}
+
+func (u *UserServiceImpl) RefreshAccessToken(ctx context.Context, refreshToken string) (*dto.RefreshTokenResultDto, *dto.ResponseError) {
+	refreshTokenPayload, err := u.tokenMaker.VerifyToken(refreshToken)
+
+	if err != nil {
+		return nil, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusUnauthorized,
+		}
+	}
+
+	if refreshTokenPayload.Type != utils.RefreshTokenType {
+		return nil, &dto.ResponseError{
+			Message: utils.ErrWrongTokenType.Error(),
+			Status:  http.StatusUnauthorized,
+		}
+	}
+
+	session, err := u.store.GetSessions(ctx, refreshTokenPayload.SessionID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &dto.ResponseError{
+				Message: "session not found",
+				Status:  http.StatusUnauthorized,
+			}
+		}
+
+		return nil, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	if session.IsBlocked {
+		return nil, &dto.ResponseError{
+			Message: "session is blocked",
+			Status:  http.StatusUnauthorized,
+		}
+	}
+
+	if session.Email != refreshTokenPayload.Email {
+		return nil, &dto.ResponseError{
+			Message: "session does not belong to this user",
+			Status:  http.StatusUnauthorized,
+		}
+	}
+
+	if session.RefreshToken != refreshToken {
+		return nil, &dto.ResponseError{
+			Message: "refresh token does not match session",
+			Status:  http.StatusUnauthorized,
+		}
+	}
+
+	if time.Now().After(session.ExpiresAt) {
+		return nil, &dto.ResponseError{
+			Message: "session has expired",
+			Status:  http.StatusUnauthorized,
+		}
+	}
+
+	accessToken, accessTokenPayload, err := u.tokenMaker.CreateToken(refreshTokenPayload.Email, refreshTokenPayload.Role, session.ID, utils.AccessTokenType, accessTokenDuration)
+
+	if err != nil {
+		return nil, &dto.ResponseError{
 			Message: err.Error(),
 			Status:  http.StatusInternalServerError,
 		}
 	}
 
-	return accessToken, nil
+	return &dto.RefreshTokenResultDto{
+		AccessToken:          accessToken,
+		AccessTokenExpiresAt: accessTokenPayload.ExpiredAt,
+	}, nil
+}
+
+func (u *UserServiceImpl) ValidateSession(ctx context.Context, sessionId uuid.UUID, email string) *dto.ResponseError {
+	session, err := u.store.GetSessions(ctx, sessionId)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &dto.ResponseError{
+				Message: "session not found",
+				Status:  http.StatusUnauthorized,
+			}
+		}
+
+		return &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	if session.IsBlocked {
+		return &dto.ResponseError{
+			Message: "session is blocked",
+			Status:  http.StatusUnauthorized,
+		}
+	}
+
+	if session.Email != email {
+		return &dto.ResponseError{
+			Message: "session does not belong to this user",
+			Status:  http.StatusUnauthorized,
+		}
+	}
+
+	return nil
+}
+
+func (u *UserServiceImpl) LogoutUser(ctx context.Context, sessionId uuid.UUID) *dto.ResponseError {
+	err := u.store.BlockSession(ctx, sessionId)
+
+	if err != nil {
+		return &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	return nil
+}
+
+func (u *UserServiceImpl) LogoutUserEverywhere(ctx context.Context, email string) *dto.ResponseError {
+	err := u.store.BlockSessionsByEmail(ctx, email)
+
+	if err != nil {
+		return &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	return nil
+}
+
+func (u *UserServiceImpl) RevokeUserSessions(ctx context.Context, email string, role string) *dto.ResponseError {
+	if role != utils.AdminRole && role != utils.BankerRole {
+		return &dto.ResponseError{
+			Message: "You have no permission for this action",
+			Status:  http.StatusUnauthorized,
+		}
+	}
+
+	_, err := u.store.GetUser(ctx, email)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &dto.ResponseError{
+				Message: err.Error(),
+				Status:  http.StatusNotFound,
+			}
+		}
+
+		return &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	return u.LogoutUserEverywhere(ctx, email)
+}
+
+func (u *UserServiceImpl) ListSessions(ctx context.Context, email string, currentSessionId uuid.UUID) ([]*dto.SessionDto, *dto.ResponseError) {
+	sessions, err := u.store.ListActiveSessions(ctx, email)
+
+	if err != nil {
+		return nil, &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	// never hand out the refresh tokens stored with the sessions
+	sessionList := make([]*dto.SessionDto, 0, len(sessions))
+	for _, session := range sessions {
+		sessionList = append(sessionList, &dto.SessionDto{
+			ID:        session.ID,
+			UserAgent: session.UserAgent,
+			ClientIp:  session.ClientIp,
+			Current:   session.ID == currentSessionId,
+			CreatedAt: session.CreatedAt,
+			ExpiresAt: session.ExpiresAt,
+		})
+	}
+
+	return sessionList, nil
+}
+
+func (u *UserServiceImpl) RevokeSession(ctx context.Context, sessionId uuid.UUID, email string) *dto.ResponseError {
+	session, err := u.store.GetSessions(ctx, sessionId)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &dto.ResponseError{
+				Message: "session not found",
+				Status:  http.StatusNotFound,
+			}
+		}
+
+		return &dto.ResponseError{
+			Message: err.Error(),
+			Status:  http.StatusInternalServerError,
+		}
+	}
+
+	// do not reveal that sessions of other users exist
+	if session.Email != email {
+		return &dto.ResponseError{
+			Message: "session not found",
+			Status:  http.StatusNotFound,
+		}
+	}
+
+	return u.LogoutUser(ctx, sessionId)
 }
 
 var _ UserServiceInterface = (*UserServiceImpl)(nil)
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// cursorPrefix versions the cursor format so that it can be changed without misreading old cursors
+const cursorPrefix = "id:"
+
+// EncodeCursor returns an opaque page cursor that points behind the row with the given id
+func EncodeCursor(id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorPrefix + strconv.FormatInt(id, 10)))
+}
+
+// DecodeCursor returns the id behind which the next page starts. An empty cursor starts at the first row.
+func DecodeCursor(cursor string) (int64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+
+	value, found := strings.CutPrefix(string(decoded), cursorPrefix)
+
+	if !found {
+		return 0, ErrInvalidCursor
+	}
+
+	id, err := strconv.ParseInt(value, 10, 64)
+
+	if err != nil || id < 0 {
+		return 0, ErrInvalidCursor
+	}
+
+	return id, nil
+}
@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	for _, id := range []int64{0, 1, 9223372036854775807} {
+		cursor := EncodeCursor(id)
+		require.NotContains(t, cursor, "=")
+
+		decodedId, err := DecodeCursor(cursor)
+		require.NoError(t, err)
+		require.Equal(t, id, decodedId)
+	}
+}
+
+func TestDecodeEmptyCursor(t *testing.T) {
+	id, err := DecodeCursor("")
+	require.NoError(t, err)
+	require.Equal(t, int64(0), id)
+}
+
+func TestDecodeInvalidCursor(t *testing.T) {
+	invalidCursors := []string{
+		"not base64!",
+		base64.RawURLEncoding.EncodeToString([]byte("42")),
+		base64.RawURLEncoding.EncodeToString([]byte("id:abc")),
+		base64.RawURLEncoding.EncodeToString([]byte("id:-1")),
+	}
+
+	for _, cursor := range invalidCursors {
+		_, err := DecodeCursor(cursor)
+		require.ErrorIs(t, err, ErrInvalidCursor)
+	}
+}
@@ -0,0 +1,211 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var ErrExchangeRateNotFound = errors.New("no exchange rate for currency pair")
+
+var ErrAmountTooLarge = errors.New("amount is too large to be converted")
+
+// ExchangeRate is a rate in units of 10^-8, so that amounts are converted in integer arithmetic
+type ExchangeRate int64
+
+const exchangeRateDecimals = 8
+
+// ExchangeRateScale is the number of units of a rate of 1
+const ExchangeRateScale ExchangeRate = 100_000_000
+
+// ParseExchangeRate parses a positive decimal rate. Digits after the 8th decimal are rounded half up
+func ParseExchangeRate(value string) (ExchangeRate, error) {
+	rat, ok := new(big.Rat).SetString(value)
+
+	if !ok || rat.Sign() <= 0 {
+		return 0, fmt.Errorf("invalid exchange rate %q", value)
+	}
+
+	units, ok := roundHalfUp(new(big.Int).Mul(rat.Num(), big.NewInt(int64(ExchangeRateScale))), rat.Denom())
+
+	if !ok || units <= 0 {
+		return 0, fmt.Errorf("invalid exchange rate %q", value)
+	}
+
+	return ExchangeRate(units), nil
+}
+
+// Convert returns the amount in the target currency of the rate, rounded half up to the minor unit
+func (r ExchangeRate) Convert(amount int64) (int64, error) {
+	return MulDivRound(amount, int64(r), int64(ExchangeRateScale))
+}
+
+// Inverse returns the rate of the opposite currency pair, rounded half up
+func (r ExchangeRate) Inverse() ExchangeRate {
+	units, _ := MulDivRound(int64(ExchangeRateScale), int64(ExchangeRateScale), int64(r))
+	return ExchangeRate(units)
+}
+
+// Float64 returns the rate as a floating point number, only meant for display
+func (r ExchangeRate) Float64() float64 {
+	return float64(r) / float64(ExchangeRateScale)
+}
+
+// String returns the rate as a decimal number without trailing zeros, e.g. 1.25
+func (r ExchangeRate) String() string {
+	sign := ""
+	units := int64(r)
+
+	if units < 0 {
+		sign, units = "-", -units
+	}
+
+	whole, fraction := units/int64(ExchangeRateScale), units%int64(ExchangeRateScale)
+
+	if fraction == 0 {
+		return sign + strconv.FormatInt(whole, 10)
+	}
+
+	decimals := strings.TrimRight(fmt.Sprintf("%0*d", exchangeRateDecimals, fraction), "0")
+	return sign + strconv.FormatInt(whole, 10) + "." + decimals
+}
+
+// MarshalJSON encodes the rate as a decimal number like before rates were stored as integers
+func (r ExchangeRate) MarshalJSON() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+func (r *ExchangeRate) UnmarshalJSON(data []byte) error {
+	rate, err := ParseExchangeRate(string(data))
+
+	if err != nil {
+		return err
+	}
+
+	*r = rate
+	return nil
+}
+
+// RatioExchangeRate returns the rate that converts amount into toAmount, rounded half up
+func RatioExchangeRate(amount int64, toAmount int64) (ExchangeRate, error) {
+	units, err := MulDivRound(toAmount, int64(ExchangeRateScale), amount)
+	return ExchangeRate(units), err
+}
+
+// MulDivRound returns a * b / c for positive numbers, rounded half up. It fails with ErrAmountTooLarge if the result
+// does not fit into an int64
+func MulDivRound(a int64, b int64, c int64) (int64, error) {
+	result, ok := roundHalfUp(new(big.Int).Mul(big.NewInt(a), big.NewInt(b)), big.NewInt(c))
+
+	if !ok {
+		return 0, ErrAmountTooLarge
+	}
+
+	return result, nil
+}
+
+// roundHalfUp divides the positive numbers and rounds the quotient half up
+func roundHalfUp(dividend *big.Int, divisor *big.Int) (int64, bool) {
+	quotient, remainder := new(big.Int).QuoRem(dividend, divisor, new(big.Int))
+
+	if remainder.Lsh(remainder, 1).Cmp(divisor) >= 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+
+	return quotient.Int64(), quotient.IsInt64()
+}
+
+// ExchangeRateProvider returns the rate to convert an amount in one currency into another currency
+type ExchangeRateProvider interface {
+	GetRate(ctx context.Context, fromCurrency string, toCurrency string) (ExchangeRate, error)
+}
+
+// StaticExchangeRateProvider serves fixed rates from the configuration, meant for local use and tests
+type StaticExchangeRateProvider struct {
+	rates map[string]ExchangeRate
+}
+
+func NewStaticExchangeRateProvider(rates map[string]ExchangeRate) *StaticExchangeRateProvider {
+	return &StaticExchangeRateProvider{
+		rates: rates,
+	}
+}
+
+// GetRate returns the configured rate of the currency pair. If only the opposite pair is configured its inverse is used.
+func (p *StaticExchangeRateProvider) GetRate(ctx context.Context, fromCurrency string, toCurrency string) (ExchangeRate, error) {
+	if fromCurrency == toCurrency {
+		return ExchangeRateScale, nil
+	}
+
+	if rate, ok := p.rates[currencyPair(fromCurrency, toCurrency)]; ok {
+		return rate, nil
+	}
+
+	if rate, ok := p.rates[currencyPair(toCurrency, fromCurrency)]; ok {
+		return rate.Inverse(), nil
+	}
+
+	return 0, fmt.Errorf("%w %s", ErrExchangeRateNotFound, currencyPair(fromCurrency, toCurrency))
+}
+
+// ParseExchangeRates parses a list of "<from currency>/<to currency>:<rate>" entries separated by commas or new lines
+func ParseExchangeRates(rateList string) (map[string]ExchangeRate, error) {
+	rates := make(map[string]ExchangeRate)
+
+	entries := strings.FieldsFunc(rateList, func(r rune) bool {
+		return r == ',' || r == '\n' || r == '\r'
+	})
+
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+
+		pair, encodedRate, found := strings.Cut(entry, ":")
+		fromCurrency, toCurrency, isPair := strings.Cut(strings.TrimSpace(pair), "/")
+
+		if !found || !isPair || fromCurrency == "" || toCurrency == "" {
+			return nil, fmt.Errorf("invalid exchange rate entry %q, expected <from currency>/<to currency>:<rate>", entry)
+		}
+
+		rate, err := ParseExchangeRate(strings.TrimSpace(encodedRate))
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid exchange rate for %s", pair)
+		}
+
+		key := currencyPair(strings.TrimSpace(fromCurrency), strings.TrimSpace(toCurrency))
+
+		if _, exists := rates[key]; exists {
+			return nil, fmt.Errorf("duplicate exchange rate for %s", key)
+		}
+
+		rates[key] = rate
+	}
+
+	return rates, nil
+}
+
+// LoadExchangeRates combines the rates from the rate list and from the optional rate file
+func LoadExchangeRates(rateList string, rateFile string) (map[string]ExchangeRate, error) {
+	if rateFile != "" {
+		content, err := os.ReadFile(rateFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read exchange rate file: %w", err)
+		}
+
+		rateList = rateList + "\n" + string(content)
+	}
+
+	return ParseExchangeRates(rateList)
+}
+
+func currencyPair(fromCurrency string, toCurrency string) string {
+	return strings.ToUpper(fromCurrency) + "/" + strings.ToUpper(toCurrency)
+}
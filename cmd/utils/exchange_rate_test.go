@@ -0,0 +1,113 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExchangeRates(t *testing.T) {
+	rates, err := ParseExchangeRates("EUR/USD:1.08, # comment\nusd/jpy: 150")
+	require.NoError(t, err)
+	require.Equal(t, map[string]ExchangeRate{"EUR/USD": 108_000_000, "USD/JPY": 15_000_000_000}, rates)
+
+	_, err = ParseExchangeRates("EUR/USD:1.08,EUR/USD:1.09")
+	require.Error(t, err)
+
+	_, err = ParseExchangeRates("EURUSD:1.08")
+	require.Error(t, err)
+
+	_, err = ParseExchangeRates("EUR/USD:-1")
+	require.Error(t, err)
+}
+
+func TestLoadExchangeRatesFromFile(t *testing.T) {
+	rateFile := filepath.Join(t.TempDir(), "rates")
+	err := os.WriteFile(rateFile, []byte("USD/JPY:150\n"), 0600)
+	require.NoError(t, err)
+
+	rates, err := LoadExchangeRates("EUR/USD:1.08", rateFile)
+	require.NoError(t, err)
+	require.Len(t, rates, 2)
+
+	_, err = LoadExchangeRates("", filepath.Join(t.TempDir(), "missing"))
+	require.Error(t, err)
+}
+
+func TestStaticExchangeRateProvider(t *testing.T) {
+	provider := NewStaticExchangeRateProvider(map[string]ExchangeRate{"EUR/USD": 125_000_000})
+	ctx := context.Background()
+
+	rate, err := provider.GetRate(ctx, "EUR", "USD")
+	require.NoError(t, err)
+	require.Equal(t, ExchangeRate(125_000_000), rate)
+
+	rate, err = provider.GetRate(ctx, "USD", "EUR")
+	require.NoError(t, err)
+	require.Equal(t, ExchangeRate(80_000_000), rate)
+
+	rate, err = provider.GetRate(ctx, "EUR", "EUR")
+	require.NoError(t, err)
+	require.Equal(t, ExchangeRateScale, rate)
+
+	_, err = provider.GetRate(ctx, "EUR", "JPY")
+	require.ErrorIs(t, err, ErrExchangeRateNotFound)
+}
+
+func TestParseExchangeRate(t *testing.T) {
+	rate, err := ParseExchangeRate("1.08")
+	require.NoError(t, err)
+	require.Equal(t, ExchangeRate(108_000_000), rate)
+	require.Equal(t, "1.08", rate.String())
+
+	// digits after the 8th decimal are rounded half up
+	rate, err = ParseExchangeRate("0.123456785")
+	require.NoError(t, err)
+	require.Equal(t, ExchangeRate(12_345_679), rate)
+
+	for _, value := range []string{"", "abc", "0", "-1", "0.000000001"} {
+		_, err = ParseExchangeRate(value)
+		require.Error(t, err, value)
+	}
+}
+
+func TestExchangeRateConvert(t *testing.T) {
+	rate := ExchangeRate(125_000_000)
+
+	toAmount, err := rate.Convert(80)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), toAmount)
+
+	// half a minor unit is rounded up
+	toAmount, err = ExchangeRate(150_000_000).Convert(1)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), toAmount)
+
+	toAmount, err = ExchangeRate(149_999_999).Convert(1)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), toAmount)
+
+	// large amounts are converted without losing precision
+	toAmount, err = ExchangeRate(108_000_000).Convert(9_007_199_254_740_993)
+	require.NoError(t, err)
+	require.Equal(t, int64(9_727_775_195_120_272), toAmount)
+
+	_, err = ExchangeRate(200_000_000).Convert(math.MaxInt64)
+	require.ErrorIs(t, err, ErrAmountTooLarge)
+}
+
+func TestExchangeRateJSON(t *testing.T) {
+	encoded, err := json.Marshal(map[string]ExchangeRate{"rate": 125_000_000})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"rate":1.25}`, string(encoded))
+
+	var decoded map[string]ExchangeRate
+	err = json.Unmarshal(encoded, &decoded)
+	require.NoError(t, err)
+	require.Equal(t, ExchangeRate(125_000_000), decoded["rate"])
+}
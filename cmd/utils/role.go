@@ -5,3 +5,5 @@ const (
 	BankerRole   = "banker"
 	AdminRole    = "admin"
 )
+
+var AllRoles = []string{CustomerRole, BankerRole, AdminRole}
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+// RoutePolicy describes who is allowed to call a route. Public routes do not need an access token.
+type RoutePolicy struct {
+	Public       bool
+	AllowedRoles []string
+}
+
+func PublicRoute() RoutePolicy {
+	return RoutePolicy{
+		Public: true,
+	}
+}
+
+func AllowRoles(roles ...string) RoutePolicy {
+	return RoutePolicy{
+		AllowedRoles: roles,
+	}
+}
+
+func (p RoutePolicy) Allows(role string) bool {
+	return slices.Contains(p.AllowedRoles, role)
+}
+
+// RouteRegistry registers handlers on a http.ServeMux together with their policy.
+// Policies are resolved with the same pattern matching as the mux, so the route that handles
+// a request is always the route whose policy is applied.
+type RouteRegistry struct {
+	mux      *http.ServeMux
+	policies map[string]RoutePolicy
+}
+
+func NewRouteRegistry() *RouteRegistry {
+	return &RouteRegistry{
+		mux:      http.NewServeMux(),
+		policies: make(map[string]RoutePolicy),
+	}
+}
+
+func (r *RouteRegistry) HandleFunc(pattern string, policy RoutePolicy, handler http.HandlerFunc) {
+	if !policy.Public && len(policy.AllowedRoles) == 0 {
+		panic(fmt.Sprintf("route %q is neither public nor allows any role", pattern))
+	}
+
+	r.mux.HandleFunc(pattern, handler)
+	r.policies[pattern] = policy
+}
+
+// Policy returns the policy of the route that matches the request.
+// If no route matches, ok is false and the mux answers the request with 404 or 405.
+func (r *RouteRegistry) Policy(req *http.Request) (policy RoutePolicy, ok bool) {
+	_, pattern := r.mux.Handler(req)
+
+	policy, ok = r.policies[pattern]
+	return policy, ok
+}
+
+func (r *RouteRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mux.ServeHTTP(w, req)
+}
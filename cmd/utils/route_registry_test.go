@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRouteRegistry() *RouteRegistry {
+	routes := NewRouteRegistry()
+	handler := func(w http.ResponseWriter, r *http.Request) {}
+
+	routes.HandleFunc("POST /users/login", PublicRoute(), handler)
+	routes.HandleFunc("GET /users/me/sessions", AllowRoles(AllRoles...), handler)
+	routes.HandleFunc("DELETE /users/{email}/sessions", AllowRoles(BankerRole, AdminRole), handler)
+	routes.HandleFunc("DELETE /users/me/sessions/{id}", AllowRoles(AllRoles...), handler)
+	routes.HandleFunc("GET /accounts", AllowRoles(BankerRole, AdminRole), handler)
+	routes.HandleFunc("GET /accounts/{id}", AllowRoles(AllRoles...), handler)
+
+	return routes
+}
+
+func TestRouteRegistryPolicy(t *testing.T) {
+	routes := newTestRouteRegistry()
+
+	testCases := []struct {
+		method string
+		path   string
+		public bool
+		role   string
+		allows bool
+	}{
+		{http.MethodPost, "/users/login", true, "", false},
+		{http.MethodGet, "/accounts", false, CustomerRole, false},
+		{http.MethodGet, "/accounts", false, BankerRole, true},
+		{http.MethodGet, "/accounts/1", false, CustomerRole, true},
+		{http.MethodHead, "/accounts/1", false, CustomerRole, true},
+		{http.MethodDelete, "/users/max@mustermann.de/sessions", false, CustomerRole, false},
+		{http.MethodDelete, "/users/me/sessions/0190b1e4-6d3c-7a5e-8f1a-2b3c4d5e6f70", false, CustomerRole, true},
+	}
+
+	for _, tc := range testCases {
+		policy, ok := routes.Policy(httptest.NewRequest(tc.method, tc.path, nil))
+		require.True(t, ok, "%s %s", tc.method, tc.path)
+		require.Equal(t, tc.public, policy.Public, "%s %s", tc.method, tc.path)
+		require.Equal(t, tc.allows, policy.Allows(tc.role), "%s %s", tc.method, tc.path)
+	}
+}
+
+func TestRouteRegistryUnknownRoute(t *testing.T) {
+	routes := newTestRouteRegistry()
+	request := httptest.NewRequest(http.MethodGet, "/unknown", nil)
+
+	_, ok := routes.Policy(request)
+	require.False(t, ok)
+
+	recorder := httptest.NewRecorder()
+	routes.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusNotFound, recorder.Result().StatusCode)
+}
+
+func TestRouteRegistryWrongMethod(t *testing.T) {
+	routes := newTestRouteRegistry()
+	request := httptest.NewRequest(http.MethodPut, "/accounts", nil)
+
+	_, ok := routes.Policy(request)
+	require.False(t, ok)
+
+	recorder := httptest.NewRecorder()
+	routes.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusMethodNotAllowed, recorder.Result().StatusCode)
+	require.Equal(t, "GET, HEAD", recorder.Result().Header.Get("Allow"))
+}
+
+func TestRouteRegistryRequiresPolicy(t *testing.T) {
+	routes := NewRouteRegistry()
+
+	require.Panics(t, func() {
+		routes.HandleFunc("GET /accounts", RoutePolicy{}, func(w http.ResponseWriter, r *http.Request) {})
+	})
+}
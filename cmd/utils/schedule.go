@@ -0,0 +1,36 @@
+package utils
+
+import "time"
+
+// frequencies of standing orders
+const (
+	FrequencyOnce    = "once"
+	FrequencyDaily   = "daily"
+	FrequencyWeekly  = "weekly"
+	FrequencyMonthly = "monthly"
+)
+
+// ScheduledExecution returns the time of the n-th execution of a schedule, starting with 0 at startAt.
+// Monthly schedules keep the day of startAt and fall back to the last day of shorter months, so a schedule
+// starting on January 31st is executed on February 28th and March 31st.
+// It returns false if the schedule has less than n+1 executions.
+func ScheduledExecution(startAt time.Time, frequency string, n int32) (time.Time, bool) {
+	switch frequency {
+	case FrequencyOnce:
+		return startAt, n == 0
+	case FrequencyDaily:
+		return startAt.AddDate(0, 0, int(n)), true
+	case FrequencyWeekly:
+		return startAt.AddDate(0, 0, 7*int(n)), true
+	case FrequencyMonthly:
+		firstOfMonth := time.Date(startAt.Year(), startAt.Month()+time.Month(n), 1, startAt.Hour(), startAt.Minute(), startAt.Second(), startAt.Nanosecond(), startAt.Location())
+		day := min(startAt.Day(), daysInMonth(firstOfMonth))
+		return firstOfMonth.AddDate(0, 0, day-1), true
+	default:
+		return time.Time{}, false
+	}
+}
+
+func daysInMonth(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()
+}
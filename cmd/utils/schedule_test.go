@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduledExecutionOnce(t *testing.T) {
+	startAt := time.Date(2024, time.March, 15, 8, 0, 0, 0, time.UTC)
+
+	execution, ok := ScheduledExecution(startAt, FrequencyOnce, 0)
+	require.True(t, ok)
+	require.Equal(t, startAt, execution)
+
+	_, ok = ScheduledExecution(startAt, FrequencyOnce, 1)
+	require.False(t, ok)
+}
+
+func TestScheduledExecutionDailyAndWeekly(t *testing.T) {
+	startAt := time.Date(2024, time.February, 27, 8, 0, 0, 0, time.UTC)
+
+	execution, ok := ScheduledExecution(startAt, FrequencyDaily, 3)
+	require.True(t, ok)
+	require.Equal(t, time.Date(2024, time.March, 1, 8, 0, 0, 0, time.UTC), execution)
+
+	execution, ok = ScheduledExecution(startAt, FrequencyWeekly, 2)
+	require.True(t, ok)
+	require.Equal(t, time.Date(2024, time.March, 12, 8, 0, 0, 0, time.UTC), execution)
+}
+
+func TestScheduledExecutionMonthlyKeepsDayOfMonth(t *testing.T) {
+	startAt := time.Date(2024, time.January, 31, 8, 0, 0, 0, time.UTC)
+
+	expected := []time.Time{
+		time.Date(2024, time.January, 31, 8, 0, 0, 0, time.UTC),
+		time.Date(2024, time.February, 29, 8, 0, 0, 0, time.UTC),
+		time.Date(2024, time.March, 31, 8, 0, 0, 0, time.UTC),
+		time.Date(2024, time.April, 30, 8, 0, 0, 0, time.UTC),
+		time.Date(2025, time.January, 31, 8, 0, 0, 0, time.UTC),
+	}
+
+	for i, n := range []int32{0, 1, 2, 3, 12} {
+		execution, ok := ScheduledExecution(startAt, FrequencyMonthly, n)
+		require.True(t, ok)
+		require.Equal(t, expected[i], execution)
+	}
+}
+
+func TestScheduledExecutionUnknownFrequency(t *testing.T) {
+	_, ok := ScheduledExecution(time.Now(), "yearly", 0)
+	require.False(t, ok)
+}
@@ -0,0 +1,125 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"aidanwoods.dev/go-paseto"
+)
+
+// ParseSymmetricKeys parses a list of "<key id>:<key>" pairs separated by commas or new lines.
+// Every key has to be 32 bytes long and can be encoded as hex or base64.
+func ParseSymmetricKeys(keyList string) (map[string]paseto.V4SymmetricKey, error) {
+	return parseKeys(keyList, []int{32}, func(keyBytes []byte) (paseto.V4SymmetricKey, error) {
+		return paseto.V4SymmetricKeyFromBytes(keyBytes)
+	})
+}
+
+// ParseAsymmetricSecretKeys parses a list of "<key id>:<key>" pairs separated by commas or new lines.
+// Every key is either a 32 byte Ed25519 seed or a 64 byte Ed25519 private key encoded as hex or base64.
+func ParseAsymmetricSecretKeys(keyList string) (map[string]paseto.V4AsymmetricSecretKey, error) {
+	return parseKeys(keyList, []int{ed25519.SeedSize, ed25519.PrivateKeySize}, func(keyBytes []byte) (paseto.V4AsymmetricSecretKey, error) {
+		if len(keyBytes) == ed25519.SeedSize {
+			return paseto.NewV4AsymmetricSecretKeyFromEd25519(ed25519.NewKeyFromSeed(keyBytes))
+		}
+
+		return paseto.NewV4AsymmetricSecretKeyFromBytes(keyBytes)
+	})
+}
+
+// LoadSymmetricKeys combines the keys from the key list and from the optional key file
+func LoadSymmetricKeys(keyList string, keyFile string) (map[string]paseto.V4SymmetricKey, error) {
+	return loadKeys(keyList, keyFile, ParseSymmetricKeys)
+}
+
+// LoadAsymmetricSecretKeys combines the keys from the key list and from the optional key file
+func LoadAsymmetricSecretKeys(keyList string, keyFile string) (map[string]paseto.V4AsymmetricSecretKey, error) {
+	return loadKeys(keyList, keyFile, ParseAsymmetricSecretKeys)
+}
+
+func loadKeys[K any](keyList string, keyFile string, parse func(string) (map[string]K, error)) (map[string]K, error) {
+	if keyFile != "" {
+		content, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read key file: %w", err)
+		}
+
+		keyList = keyList + "\n" + string(content)
+	}
+
+	keys, err := parse(keyList)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no token keys configured")
+	}
+
+	return keys, nil
+}
+
+// parseKeys decodes the keys of the list, keySizes are the valid lengths of a decoded key in bytes
+func parseKeys[K any](keyList string, keySizes []int, newKey func([]byte) (K, error)) (map[string]K, error) {
+	keys := make(map[string]K)
+
+	entries := strings.FieldsFunc(keyList, func(r rune) bool {
+		return r == ',' || r == '\n' || r == '\r'
+	})
+
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+
+		keyId, encodedKey, found := strings.Cut(entry, ":")
+		keyId = strings.TrimSpace(keyId)
+		encodedKey = strings.TrimSpace(encodedKey)
+
+		if !found || keyId == "" || encodedKey == "" {
+			return nil, fmt.Errorf("invalid key entry %q, expected <key id>:<key>", entry)
+		}
+
+		if _, exists := keys[keyId]; exists {
+			return nil, fmt.Errorf("duplicate key id %q", keyId)
+		}
+
+		keyBytes, err := decodeKey(encodedKey, keySizes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key with id %q: %w", keyId, err)
+		}
+
+		key, err := newKey(keyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key with id %q: %w", keyId, err)
+		}
+
+		keys[keyId] = key
+	}
+
+	return keys, nil
+}
+
+// decodeKey decodes a hex or base64 encoded key. A decoding is only accepted if the key has one of the valid sizes,
+// because a base64 key that consists of hex characters only is also valid hex. The encoded lengths of the valid
+// sizes differ between hex and base64, so at most one decoding matches.
+func decodeKey(encodedKey string, keySizes []int) ([]byte, error) {
+	if key, err := hex.DecodeString(encodedKey); err == nil && slices.Contains(keySizes, len(key)) {
+		return key, nil
+	}
+
+	for _, encoding := range []*base64.Encoding{base64.StdEncoding, base64.URLEncoding, base64.RawStdEncoding, base64.RawURLEncoding} {
+		if key, err := encoding.DecodeString(encodedKey); err == nil && slices.Contains(keySizes, len(key)) {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("key is neither a hex nor a base64 encoded key of %v bytes", keySizes)
+}
@@ -1,9 +1,13 @@
 package utils
 
-import "time"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type TokenMaker interface {
-	CreateToken(email string, role string, duration time.Duration) (string, *TokenPayload, error)
+	CreateToken(email string, role string, sessionId uuid.UUID, tokenType string, duration time.Duration) (string, *TokenPayload, error)
 
 	VerifyToken(token string) (*TokenPayload, error)
 }
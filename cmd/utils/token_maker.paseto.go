@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"encoding/json"
+	"fmt"
 	"strings"
 	"time"
 
@@ -8,60 +10,123 @@ import (
 	"github.com/google/uuid"
 )
 
+// PasetoMaker encrypts new tokens with the current key and accepts tokens encrypted with any of its keys.
+// The id of the key is stored in the token footer so keys can be rotated without invalidating issued tokens.
 type PasetoMaker struct {
-	symmeticKey paseto.V4SymmetricKey
-	implicit    []byte
+	currentKeyId  string
+	symmetricKeys map[string]paseto.V4SymmetricKey
 }
 
-func NewPasetoMaker(symmetricKey string) *PasetoMaker {
+type tokenFooter struct {
+	KeyId string `json:"kid"`
+}
+
+func NewPasetoMaker(currentKeyId string, symmetricKeys map[string]paseto.V4SymmetricKey) (*PasetoMaker, error) {
+	if _, ok := symmetricKeys[currentKeyId]; !ok {
+		return nil, fmt.Errorf("no symmetric key with id %q", currentKeyId)
+	}
+
 	return &PasetoMaker{
-		symmeticKey: paseto.NewV4SymmetricKey(),
-		implicit:    []byte(symmetricKey),
+		currentKeyId:  currentKeyId,
+		symmetricKeys: symmetricKeys,
+	}, nil
+}
+
+func (p *PasetoMaker) CreateToken(email string, role string, sessionId uuid.UUID, tokenType string, duration time.Duration) (string, *TokenPayload, error) {
+	token, payload, err := newToken(email, role, sessionId, tokenType, duration, p.currentKeyId)
+
+	if err != nil {
+		return "", nil, err
 	}
+
+	return token.V4Encrypt(p.symmetricKeys[p.currentKeyId], nil), payload, nil
 }
 
-func (p *PasetoMaker) CreateToken(email string, role string, duration time.Duration) (string, *TokenPayload, error) {
+func (p *PasetoMaker) VerifyToken(token string) (*TokenPayload, error) {
+	parser := paseto.NewParser()
+	parser.AddRule(paseto.NotExpired())
+
+	keyId, err := getKeyIdFromFooter(parser, paseto.V4Local, token)
+
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	symmetricKey, ok := p.symmetricKeys[keyId]
+
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
+	pasrsedToken, err := parser.ParseV4Local(symmetricKey, token, nil)
+
+	if err != nil {
+		if strings.Contains(err.Error(), "expired") {
+			return nil, ErrExpiredToken
+		}
+		return nil, ErrInvalidToken
+	}
+
+	payload, err := getPayloadFromToken(pasrsedToken)
+
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	return payload, nil
+}
+
+// newToken creates a token with the claims of the payload and the key id in its footer
+func newToken(email string, role string, sessionId uuid.UUID, tokenType string, duration time.Duration, keyId string) (*paseto.Token, *TokenPayload, error) {
 	token := paseto.NewToken()
 	tokenId, err := uuid.NewRandom()
 
 	if err != nil {
-		return "", nil, err
+		return nil, nil, err
 	}
 
 	token.Set("id", tokenId.String())
 	token.Set("email", email)
 	token.Set("role", role)
+	token.Set("session_id", sessionId.String())
+	token.Set("type", tokenType)
 	token.SetIssuedAt(time.Now())
 	token.SetExpiration(time.Now().Add(duration))
 
 	payload, err := getPayloadFromToken(&token)
 
 	if err != nil {
-		return "", nil, nil
+		return nil, nil, err
+	}
+
+	footer, err := json.Marshal(&tokenFooter{KeyId: keyId})
+
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return token.V4Encrypt(p.symmeticKey, p.implicit), payload, nil
+	token.SetFooter(footer)
+
+	return &token, payload, nil
 }
 
-func (p *PasetoMaker) VerifyToken(token string) (*TokenPayload, error) {
-	parser := paseto.NewParser()
-	parser.AddRule(paseto.NotExpired())
-	pasrsedToken, err := parser.ParseV4Local(p.symmeticKey, token, p.implicit)
+// getKeyIdFromFooter reads the key id from the footer. The footer is not protected until the token
+// has been verified, so it must only be used to look up the key.
+func getKeyIdFromFooter(parser paseto.Parser, protocol paseto.Protocol, token string) (string, error) {
+	rawFooter, err := parser.UnsafeParseFooter(protocol, token)
 
 	if err != nil {
-		if strings.Contains(err.Error(), "expired") {
-			return nil, ErrExpiredToken
-		}
-		return nil, ErrInvalidToken
+		return "", err
 	}
 
-	payload, err := getPayloadFromToken(pasrsedToken)
+	var footer tokenFooter
+	err = json.Unmarshal(rawFooter, &footer)
 
 	if err != nil {
-		return nil, ErrInvalidToken
+		return "", err
 	}
 
-	return payload, nil
+	return footer.KeyId, nil
 }
 
 func getPayloadFromToken(token *paseto.Token) (*TokenPayload, error) {
@@ -70,6 +135,11 @@ func getPayloadFromToken(token *paseto.Token) (*TokenPayload, error) {
 		return nil, ErrInvalidToken
 	}
 
+	parsedId, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
 	email, err := token.GetString("email")
 	if err != nil {
 		return nil, ErrInvalidToken
@@ -80,6 +150,21 @@ func getPayloadFromToken(token *paseto.Token) (*TokenPayload, error) {
 		return nil, ErrInvalidToken
 	}
 
+	sessionId, err := token.GetString("session_id")
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	parsedSessionId, err := uuid.Parse(sessionId)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	tokenType, err := token.GetString("type")
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
 	issuedAt, err := token.GetIssuedAt()
 	if err != nil {
 		return nil, ErrInvalidToken
@@ -91,9 +176,11 @@ func getPayloadFromToken(token *paseto.Token) (*TokenPayload, error) {
 	}
 
 	return &TokenPayload{
-		ID:        uuid.MustParse(id),
+		ID:        parsedId,
 		Email:     email,
 		Role:      role,
+		SessionID: parsedSessionId,
+		Type:      tokenType,
 		IssuedAt:  issuedAt,
 		ExpiredAt: expiredAt,
 	}, nil
@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"aidanwoods.dev/go-paseto"
+	"github.com/google/uuid"
+)
+
+// PasetoPublicMaker signs tokens with the current Ed25519 secret key. Tokens can be verified by anyone who
+// knows the public keys, so other services can validate tokens without being able to create them.
+type PasetoPublicMaker struct {
+	currentKeyId string
+	secretKey    paseto.V4AsymmetricSecretKey
+	publicKeys   map[string]paseto.V4AsymmetricPublicKey
+}
+
+type PublicKey struct {
+	KeyId     string `json:"kid"`
+	Version   string `json:"version"`
+	Purpose   string `json:"purpose"`
+	PublicKey string `json:"public_key"`
+}
+
+// PublicKeyProvider is implemented by token makers whose tokens can be verified with public keys
+type PublicKeyProvider interface {
+	PublicKeys() []*PublicKey
+}
+
+func NewPasetoPublicMaker(currentKeyId string, secretKeys map[string]paseto.V4AsymmetricSecretKey) (*PasetoPublicMaker, error) {
+	secretKey, ok := secretKeys[currentKeyId]
+
+	if !ok {
+		return nil, fmt.Errorf("no secret key with id %q", currentKeyId)
+	}
+
+	// only the public part of older keys is kept to verify tokens signed before a rotation
+	publicKeys := make(map[string]paseto.V4AsymmetricPublicKey, len(secretKeys))
+	for keyId, key := range secretKeys {
+		publicKeys[keyId] = key.Public()
+	}
+
+	return &PasetoPublicMaker{
+		currentKeyId: currentKeyId,
+		secretKey:    secretKey,
+		publicKeys:   publicKeys,
+	}, nil
+}
+
+func (p *PasetoPublicMaker) CreateToken(email string, role string, sessionId uuid.UUID, tokenType string, duration time.Duration) (string, *TokenPayload, error) {
+	token, payload, err := newToken(email, role, sessionId, tokenType, duration, p.currentKeyId)
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	return token.V4Sign(p.secretKey, nil), payload, nil
+}
+
+func (p *PasetoPublicMaker) VerifyToken(token string) (*TokenPayload, error) {
+	parser := paseto.NewParser()
+	parser.AddRule(paseto.NotExpired())
+
+	keyId, err := getKeyIdFromFooter(parser, paseto.V4Public, token)
+
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	publicKey, ok := p.publicKeys[keyId]
+
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
+	parsedToken, err := parser.ParseV4Public(publicKey, token, nil)
+
+	if err != nil {
+		if strings.Contains(err.Error(), "expired") {
+			return nil, ErrExpiredToken
+		}
+		return nil, ErrInvalidToken
+	}
+
+	payload, err := getPayloadFromToken(parsedToken)
+
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	return payload, nil
+}
+
+func (p *PasetoPublicMaker) PublicKeys() []*PublicKey {
+	keys := make([]*PublicKey, 0, len(p.publicKeys))
+
+	for keyId, key := range p.publicKeys {
+		keys = append(keys, &PublicKey{
+			KeyId:     keyId,
+			Version:   "v4",
+			Purpose:   "public",
+			PublicKey: key.ExportHex(),
+		})
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].KeyId < keys[j].KeyId
+	})
+
+	return keys
+}
+
+var _ TokenMaker = (*PasetoPublicMaker)(nil)
+var _ PublicKeyProvider = (*PasetoPublicMaker)(nil)
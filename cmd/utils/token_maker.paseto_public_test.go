@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"aidanwoods.dev/go-paseto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasetoPublicMakerCreateAndVerifyToken(t *testing.T) {
+	maker, err := NewPasetoPublicMaker("key1", map[string]paseto.V4AsymmetricSecretKey{
+		"key1": paseto.NewV4AsymmetricSecretKey(),
+	})
+	require.NoError(t, err)
+
+	sessionId := uuid.New()
+	token, payload, err := maker.CreateToken("Max@Mustermann.de", BankerRole, sessionId, RefreshTokenType, time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+
+	verifiedPayload, err := maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.Equal(t, payload.ID, verifiedPayload.ID)
+	require.Equal(t, "Max@Mustermann.de", verifiedPayload.Email)
+	require.Equal(t, BankerRole, verifiedPayload.Role)
+	require.Equal(t, sessionId, verifiedPayload.SessionID)
+	require.Equal(t, RefreshTokenType, verifiedPayload.Type)
+}
+
+func TestPasetoPublicMakerVerifyWithPublicKeyOnly(t *testing.T) {
+	secretKey := paseto.NewV4AsymmetricSecretKey()
+	maker, err := NewPasetoPublicMaker("key1", map[string]paseto.V4AsymmetricSecretKey{
+		"key1": secretKey,
+	})
+	require.NoError(t, err)
+
+	token, _, err := maker.CreateToken("Max@Mustermann.de", CustomerRole, uuid.New(), AccessTokenType, time.Minute)
+	require.NoError(t, err)
+
+	// another service only knows the published public key
+	publicKeys := maker.PublicKeys()
+	require.Len(t, publicKeys, 1)
+	require.Equal(t, "key1", publicKeys[0].KeyId)
+
+	publicKey, err := paseto.NewV4AsymmetricPublicKeyFromHex(publicKeys[0].PublicKey)
+	require.NoError(t, err)
+
+	parser := paseto.NewParser()
+	parsedToken, err := parser.ParseV4Public(publicKey, token, nil)
+	require.NoError(t, err)
+
+	email, err := parsedToken.GetString("email")
+	require.NoError(t, err)
+	require.Equal(t, "Max@Mustermann.de", email)
+}
+
+func TestPasetoPublicMakerRejectsLocalTokens(t *testing.T) {
+	localMaker, err := NewPasetoMaker("key1", map[string]paseto.V4SymmetricKey{
+		"key1": paseto.NewV4SymmetricKey(),
+	})
+	require.NoError(t, err)
+
+	publicMaker, err := NewPasetoPublicMaker("key1", map[string]paseto.V4AsymmetricSecretKey{
+		"key1": paseto.NewV4AsymmetricSecretKey(),
+	})
+	require.NoError(t, err)
+
+	localToken, _, err := localMaker.CreateToken("Max@Mustermann.de", CustomerRole, uuid.New(), AccessTokenType, time.Minute)
+	require.NoError(t, err)
+
+	_, err = publicMaker.VerifyToken(localToken)
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	publicToken, _, err := publicMaker.CreateToken("Max@Mustermann.de", CustomerRole, uuid.New(), AccessTokenType, time.Minute)
+	require.NoError(t, err)
+
+	_, err = localMaker.VerifyToken(publicToken)
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestParseAsymmetricSecretKeys(t *testing.T) {
+	secretKey := paseto.NewV4AsymmetricSecretKey()
+
+	keys, err := ParseAsymmetricSecretKeys("key1:" + secretKey.ExportHex() + ",key2:" + secretKey.ExportSeedHex())
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	require.Equal(t, secretKey.ExportBytes(), keys["key1"].ExportBytes())
+	require.Equal(t, secretKey.ExportBytes(), keys["key2"].ExportBytes())
+
+	_, err = ParseAsymmetricSecretKeys("key1:abcd")
+	require.Error(t, err)
+}
@@ -0,0 +1,144 @@
+package utils
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"aidanwoods.dev/go-paseto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasetoMakerCreateAndVerifyToken(t *testing.T) {
+	maker, err := NewPasetoMaker("key1", map[string]paseto.V4SymmetricKey{
+		"key1": paseto.NewV4SymmetricKey(),
+	})
+	require.NoError(t, err)
+
+	sessionId := uuid.New()
+	token, payload, err := maker.CreateToken("Max@Mustermann.de", CustomerRole, sessionId, AccessTokenType, time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+
+	verifiedPayload, err := maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.Equal(t, payload.ID, verifiedPayload.ID)
+	require.Equal(t, "Max@Mustermann.de", verifiedPayload.Email)
+	require.Equal(t, CustomerRole, verifiedPayload.Role)
+	require.Equal(t, sessionId, verifiedPayload.SessionID)
+	require.Equal(t, AccessTokenType, verifiedPayload.Type)
+	require.WithinDuration(t, payload.ExpiredAt, verifiedPayload.ExpiredAt, time.Second)
+}
+
+func TestPasetoMakerExpiredToken(t *testing.T) {
+	maker, err := NewPasetoMaker("key1", map[string]paseto.V4SymmetricKey{
+		"key1": paseto.NewV4SymmetricKey(),
+	})
+	require.NoError(t, err)
+
+	token, _, err := maker.CreateToken("Max@Mustermann.de", CustomerRole, uuid.New(), AccessTokenType, -time.Minute)
+	require.NoError(t, err)
+
+	_, err = maker.VerifyToken(token)
+	require.ErrorIs(t, err, ErrExpiredToken)
+}
+
+func TestPasetoMakerKeyRotation(t *testing.T) {
+	oldKey := paseto.NewV4SymmetricKey()
+	newKey := paseto.NewV4SymmetricKey()
+
+	oldMaker, err := NewPasetoMaker("old", map[string]paseto.V4SymmetricKey{
+		"old": oldKey,
+	})
+	require.NoError(t, err)
+
+	oldToken, _, err := oldMaker.CreateToken("Max@Mustermann.de", CustomerRole, uuid.New(), AccessTokenType, time.Minute)
+	require.NoError(t, err)
+
+	// after the rotation new tokens are encrypted with the new key but old tokens are still accepted
+	rotatedMaker, err := NewPasetoMaker("new", map[string]paseto.V4SymmetricKey{
+		"old": oldKey,
+		"new": newKey,
+	})
+	require.NoError(t, err)
+
+	_, err = rotatedMaker.VerifyToken(oldToken)
+	require.NoError(t, err)
+
+	newToken, _, err := rotatedMaker.CreateToken("Max@Mustermann.de", CustomerRole, uuid.New(), AccessTokenType, time.Minute)
+	require.NoError(t, err)
+
+	_, err = oldMaker.VerifyToken(newToken)
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	// once the old key is removed its tokens are refused
+	retiredMaker, err := NewPasetoMaker("new", map[string]paseto.V4SymmetricKey{
+		"new": newKey,
+	})
+	require.NoError(t, err)
+
+	_, err = retiredMaker.VerifyToken(oldToken)
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	_, err = retiredMaker.VerifyToken(newToken)
+	require.NoError(t, err)
+}
+
+func TestPasetoMakerMalformedTokenId(t *testing.T) {
+	symmetricKey := paseto.NewV4SymmetricKey()
+	maker, err := NewPasetoMaker("key1", map[string]paseto.V4SymmetricKey{
+		"key1": symmetricKey,
+	})
+	require.NoError(t, err)
+
+	// a correctly encrypted token whose id is not a uuid
+	token, _, err := newToken("Max@Mustermann.de", CustomerRole, uuid.New(), AccessTokenType, time.Minute, "key1")
+	require.NoError(t, err)
+	token.Set("id", "not-a-uuid")
+
+	_, err = maker.VerifyToken(token.V4Encrypt(symmetricKey, nil))
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestNewPasetoMakerUnknownKeyId(t *testing.T) {
+	_, err := NewPasetoMaker("missing", map[string]paseto.V4SymmetricKey{
+		"key1": paseto.NewV4SymmetricKey(),
+	})
+	require.Error(t, err)
+}
+
+func TestParseSymmetricKeys(t *testing.T) {
+	hexKey := paseto.NewV4SymmetricKey()
+	base64Key := paseto.NewV4SymmetricKey()
+
+	keys, err := ParseSymmetricKeys("key1:" + hexKey.ExportHex() + ",\nkey2:" + base64.StdEncoding.EncodeToString(base64Key.ExportBytes()))
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	require.Equal(t, hexKey.ExportBytes(), keys["key1"].ExportBytes())
+	require.Equal(t, base64Key.ExportBytes(), keys["key2"].ExportBytes())
+
+	_, err = ParseSymmetricKeys("key1:" + hexKey.ExportHex() + ",key1:" + hexKey.ExportHex())
+	require.Error(t, err)
+
+	_, err = ParseSymmetricKeys("key1:tooshort")
+	require.Error(t, err)
+
+	_, err = ParseSymmetricKeys(hexKey.ExportHex())
+	require.Error(t, err)
+}
+
+func TestDecodeKeyChecksKeySize(t *testing.T) {
+	// a base64 encoded 64 byte key that consists of hex characters only, decoded as hex it would have 43 bytes
+	encodedKey := strings.Repeat("ab", 43)
+	expectedKey, err := base64.RawStdEncoding.DecodeString(encodedKey)
+	require.NoError(t, err)
+
+	key, err := decodeKey(encodedKey, []int{64})
+	require.NoError(t, err)
+	require.Equal(t, expectedKey, key)
+
+	_, err = decodeKey(encodedKey, []int{32})
+	require.Error(t, err)
+}
@@ -8,19 +8,29 @@ import (
 )
 
 var (
-	ErrInvalidToken = errors.New("token is invalid")
-	ErrExpiredToken = errors.New("token has expired")
+	ErrInvalidToken   = errors.New("token is invalid")
+	ErrExpiredToken   = errors.New("token has expired")
+	ErrWrongTokenType = errors.New("token has the wrong type")
+)
+
+// types of the tokens. Access and refresh token share the session id, so only access tokens authorize requests
+// and only refresh tokens create new access tokens
+const (
+	AccessTokenType  = "access"
+	RefreshTokenType = "refresh"
 )
 
 type TokenPayload struct {
 	ID        uuid.UUID `json:"id"`
 	Email     string    `json:"email"`
 	Role      string    `json:"role"`
+	SessionID uuid.UUID `json:"session_id"`
+	Type      string    `json:"type"`
 	IssuedAt  time.Time `json:"issued_at"`
 	ExpiredAt time.Time `json:"expired_at"`
 }
 
-func NewPayload(email string, role string, duration time.Duration) (*TokenPayload, error) {
+func NewPayload(email string, role string, sessionId uuid.UUID, tokenType string, duration time.Duration) (*TokenPayload, error) {
 	tokenId, err := uuid.NewRandom()
 	if err != nil {
 		return nil, err
@@ -30,6 +40,8 @@ func NewPayload(email string, role string, duration time.Duration) (*TokenPayloa
 		ID:        tokenId,
 		Email:     email,
 		Role:      role,
+		SessionID: sessionId,
+		Type:      tokenType,
 		IssuedAt:  time.Now(),
 		ExpiredAt: time.Now().Add(duration),
 	}
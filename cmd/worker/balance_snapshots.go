@@ -0,0 +1,25 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// BalanceSnapshotBuilder snapshots the daily balances of the accounts for the days that are over at the given time
+type BalanceSnapshotBuilder interface {
+	BuildBalanceSnapshots(ctx context.Context, now time.Time) (int, error)
+}
+
+// RunBalanceSnapshots builds the missing daily balance snapshots every interval until the context is cancelled.
+func RunBalanceSnapshots(ctx context.Context, builder BalanceSnapshotBuilder, interval time.Duration) {
+	runEvery(ctx, interval, func(ctx context.Context, now time.Time) {
+		days, err := builder.BuildBalanceSnapshots(ctx, now)
+
+		if err != nil {
+			log.Println("cannot build balance snapshots: ", err)
+		} else if days > 0 {
+			log.Printf("built balance snapshots for %d days", days)
+		}
+	})
+}
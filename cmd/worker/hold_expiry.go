@@ -0,0 +1,25 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// HoldExpirer releases the holds whose expiry time has passed at the given time
+type HoldExpirer interface {
+	ExpireHolds(ctx context.Context, now time.Time) (int, error)
+}
+
+// RunHoldExpiry releases expired holds every interval until the context is cancelled.
+func RunHoldExpiry(ctx context.Context, expirer HoldExpirer, interval time.Duration) {
+	runEvery(ctx, interval, func(ctx context.Context, now time.Time) {
+		count, err := expirer.ExpireHolds(ctx, now)
+
+		if err != nil {
+			log.Println("cannot expire holds: ", err)
+		} else if count > 0 {
+			log.Printf("expired %d holds", count)
+		}
+	})
+}
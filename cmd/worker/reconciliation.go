@@ -0,0 +1,38 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"kara-bank/services"
+	"log"
+	"time"
+)
+
+// Reconciler checks that the account balances match the entries and the general ledger
+type Reconciler interface {
+	Reconcile(ctx context.Context, now time.Time) (*services.ReconciliationReport, error)
+}
+
+// RunReconciliation reconciles the ledger every interval and logs the report as json if it found inconsistencies.
+func RunReconciliation(ctx context.Context, reconciler Reconciler, interval time.Duration) {
+	runEvery(ctx, interval, func(ctx context.Context, now time.Time) {
+		report, err := reconciler.Reconcile(ctx, now)
+
+		if err != nil {
+			log.Println("cannot reconcile ledger: ", err)
+			return
+		}
+
+		if report.Consistent {
+			return
+		}
+
+		reportJson, err := json.Marshal(report)
+
+		if err != nil {
+			log.Println("cannot marshal reconciliation report: ", err)
+		} else {
+			log.Printf("reconciliation found inconsistencies: %s", reportJson)
+		}
+	})
+}
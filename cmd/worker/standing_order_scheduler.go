@@ -0,0 +1,25 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// StandingOrderExecutor executes the standing orders that are due at the given time
+type StandingOrderExecutor interface {
+	ExecuteDueStandingOrders(ctx context.Context, now time.Time) (int, error)
+}
+
+// RunStandingOrderScheduler executes due standing orders every interval until the context is cancelled.
+func RunStandingOrderScheduler(ctx context.Context, executor StandingOrderExecutor, interval time.Duration) {
+	runEvery(ctx, interval, func(ctx context.Context, now time.Time) {
+		count, err := executor.ExecuteDueStandingOrders(ctx, now)
+
+		if err != nil {
+			log.Println("cannot execute standing orders: ", err)
+		} else if count > 0 {
+			log.Printf("executed %d standing orders", count)
+		}
+	})
+}
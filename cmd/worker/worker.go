@@ -0,0 +1,24 @@
+package worker
+
+import (
+	"context"
+	"time"
+)
+
+// runEvery runs the job right away and then every interval until the context is cancelled. Every instance of the app
+// runs the background jobs, so a job must be safe to run concurrently, e.g. by claiming its work within a database
+// transaction or by only reading.
+func runEvery(ctx context.Context, interval time.Duration, job func(ctx context.Context, now time.Time)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		job(ctx, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
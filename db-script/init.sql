@@ -64,4 +64,398 @@ CREATE TABLE "sessions" (
 
 ALTER TABLE "sessions" ADD FOREIGN KEY ("email") REFERENCES "users" ("email");
 
-ALTER TABLE "users" ADD COLUMN "user_role" text NOT NULL DEFAULT 'customer';
\ No newline at end of file
+ALTER TABLE "users" ADD COLUMN "user_role" text NOT NULL DEFAULT 'customer';
+
+ALTER TABLE "accounts" ADD COLUMN "overdraft_limit" bigint NOT NULL DEFAULT 0 CHECK ("overdraft_limit" >= 0);
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_balance_overdraft_check" CHECK ("balance" >= -"overdraft_limit") NOT VALID;
+
+COMMENT ON COLUMN "accounts"."overdraft_limit" IS 'how far the balance may go below zero';
+
+ALTER TABLE "transfers" ADD COLUMN "to_amount" bigint;
+
+UPDATE "transfers" SET "to_amount" = "amount";
+
+ALTER TABLE "transfers" ALTER COLUMN "to_amount" SET NOT NULL;
+
+ALTER TABLE "transfers" ADD COLUMN "exchange_rate" double precision NOT NULL DEFAULT 1;
+
+COMMENT ON COLUMN "transfers"."to_amount" IS 'amount credited to the receiving account in its currency';
+
+COMMENT ON COLUMN "transfers"."exchange_rate" IS 'rate used to convert amount into to_amount';
+
+CREATE TABLE "idempotency_keys" (
+  "email" text NOT NULL,
+  "idempotency_key" text NOT NULL,
+  "request_hash" text NOT NULL,
+  "response_body" jsonb,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("email", "idempotency_key")
+);
+
+COMMENT ON COLUMN "idempotency_keys"."response_body" IS 'null while the first request with this key is in progress';
+
+ALTER TABLE "idempotency_keys" ADD FOREIGN KEY ("email") REFERENCES "users" ("email");
+
+CREATE INDEX "entries_account_id_id_idx" ON "entries" ("account_id", "id");
+
+CREATE INDEX "transfers_from_account_id_id_idx" ON "transfers" ("from_account_id", "id");
+
+CREATE INDEX "transfers_to_account_id_id_idx" ON "transfers" ("to_account_id", "id");
+
+CREATE TABLE "standing_orders" (
+  "id" bigserial PRIMARY KEY,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL CHECK ("amount" > 0),
+  "frequency" text NOT NULL CHECK ("frequency" IN ('once', 'daily', 'weekly', 'monthly')),
+  "start_at" timestamptz NOT NULL,
+  "end_at" timestamptz,
+  "status" text NOT NULL DEFAULT 'active' CHECK ("status" IN ('active', 'completed', 'failed', 'cancelled')),
+  "next_execution_at" timestamptz,
+  "execution_count" integer NOT NULL DEFAULT 0,
+  "failed_attempts" integer NOT NULL DEFAULT 0,
+  "last_error" text,
+  "last_transfer_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "standing_orders" ("from_account_id");
+
+CREATE INDEX "standing_orders_due_idx" ON "standing_orders" ("next_execution_at") WHERE "status" = 'active';
+
+COMMENT ON COLUMN "standing_orders"."next_execution_at" IS 'next attempt, also used for retries of a failed execution. null if the order is not active';
+
+COMMENT ON COLUMN "standing_orders"."execution_count" IS 'number of scheduled executions that are done, including executions that were skipped after too many failures';
+
+COMMENT ON COLUMN "standing_orders"."failed_attempts" IS 'failed attempts of the current execution';
+
+ALTER TABLE "standing_orders" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "standing_orders" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "standing_orders" ADD FOREIGN KEY ("last_transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfers" ADD COLUMN "reversal_of" bigint REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "transfers" ("reversal_of");
+
+COMMENT ON COLUMN "transfers"."reversal_of" IS 'transfer that is fully or partially reversed by this transfer';
+
+ALTER TABLE "accounts" ADD COLUMN "held_amount" bigint NOT NULL DEFAULT 0 CHECK ("held_amount" >= 0);
+
+COMMENT ON COLUMN "accounts"."held_amount" IS 'reserved for pending transfers, not available for new transfers';
+
+CREATE TABLE "transfer_approvals" (
+  "id" bigserial PRIMARY KEY,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL CHECK ("amount" > 0),
+  "initiator" text NOT NULL,
+  "status" text NOT NULL DEFAULT 'pending' CHECK ("status" IN ('pending', 'approved', 'rejected')),
+  "reviewer" text,
+  "transfer_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "decided_at" timestamptz
+);
+
+CREATE INDEX "transfer_approvals_pending_idx" ON "transfer_approvals" ("id") WHERE "status" = 'pending';
+
+COMMENT ON COLUMN "transfer_approvals"."amount" IS 'held on the sending account until the transfer is approved or rejected';
+
+COMMENT ON COLUMN "transfer_approvals"."reviewer" IS 'banker who approved or rejected the transfer, must not be the initiator';
+
+COMMENT ON COLUMN "transfer_approvals"."transfer_id" IS 'transfer that was executed after the approval';
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("initiator") REFERENCES "users" ("email");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("reviewer") REFERENCES "users" ("email");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE TABLE "holds" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL CHECK ("amount" > 0),
+  "description" text NOT NULL DEFAULT '',
+  "status" text NOT NULL DEFAULT 'active' CHECK ("status" IN ('active', 'captured', 'released', 'expired')),
+  "transfer_id" bigint,
+  "expires_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "closed_at" timestamptz
+);
+
+CREATE INDEX ON "holds" ("account_id", "id");
+
+CREATE INDEX "holds_expiry_idx" ON "holds" ("expires_at") WHERE "status" = 'active';
+
+COMMENT ON COLUMN "holds"."amount" IS 'reserved on the account while the hold is active';
+
+COMMENT ON COLUMN "holds"."transfer_id" IS 'transfer that captured the hold';
+
+COMMENT ON COLUMN "holds"."expires_at" IS 'the hold is released automatically after this time, null if it does not expire';
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "accounts" ADD COLUMN "available_balance" bigint GENERATED ALWAYS AS ("balance" - "held_amount") STORED;
+
+COMMENT ON COLUMN "accounts"."held_amount" IS 'sum of the active holds, not available for new transfers';
+
+COMMENT ON COLUMN "accounts"."available_balance" IS 'ledger balance minus the held amount';
+
+ALTER TABLE "transfer_approvals" ADD COLUMN "hold_id" bigint;
+
+COMMENT ON COLUMN "transfer_approvals"."hold_id" IS 'reserves the amount on the sending account until the transfer is approved or rejected';
+
+-- amounts of pending transfers were held without a hold before
+UPDATE "transfer_approvals" SET "hold_id" = nextval('holds_id_seq') WHERE "status" = 'pending';
+
+INSERT INTO "holds" ("id", "account_id", "amount", "description")
+SELECT "hold_id", "from_account_id", "amount", 'pending transfer to account ' || "to_account_id" FROM "transfer_approvals" WHERE "hold_id" IS NOT NULL;
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("hold_id") REFERENCES "holds" ("id");
+
+CREATE TABLE "ledger_accounts" (
+  "code" text PRIMARY KEY,
+  "name" text NOT NULL,
+  "type" text NOT NULL CHECK ("type" IN ('asset', 'liability', 'equity', 'income', 'expense')),
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "journals" (
+  "id" bigserial PRIMARY KEY,
+  "description" text NOT NULL,
+  "transfer_id" bigint UNIQUE,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "postings" (
+  "id" bigserial PRIMARY KEY,
+  "journal_id" bigint NOT NULL,
+  "ledger_account" text NOT NULL,
+  "account_id" bigint,
+  "amount" bigint NOT NULL CHECK ("amount" <> 0),
+  "currency" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "postings" ("journal_id");
+
+CREATE INDEX ON "postings" ("ledger_account", "currency");
+
+CREATE INDEX ON "postings" ("account_id");
+
+COMMENT ON TABLE "ledger_accounts" IS 'chart of accounts of the bank';
+
+COMMENT ON COLUMN "journals"."transfer_id" IS 'transfer that is booked by this journal, null for manual journals';
+
+COMMENT ON COLUMN "postings"."account_id" IS 'customer account of the posting, only for the customer deposits ledger account';
+
+COMMENT ON COLUMN "postings"."amount" IS 'debit if positive, credit if negative. The postings of a journal sum to zero per currency';
+
+ALTER TABLE "journals" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "postings" ADD FOREIGN KEY ("journal_id") REFERENCES "journals" ("id");
+
+ALTER TABLE "postings" ADD FOREIGN KEY ("ledger_account") REFERENCES "ledger_accounts" ("code");
+
+ALTER TABLE "postings" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+-- the check is deferred to the end of the transaction, so that the postings of a journal can be inserted one by one
+CREATE FUNCTION "check_journal_balanced"() RETURNS trigger AS $$
+DECLARE
+  unbalanced record;
+BEGIN
+  SELECT "currency", sum("amount") AS "total" INTO unbalanced
+  FROM "postings"
+  WHERE "journal_id" = NEW."journal_id"
+  GROUP BY "currency"
+  HAVING sum("amount") <> 0
+  LIMIT 1;
+
+  IF FOUND THEN
+    RAISE EXCEPTION 'journal % is not balanced, % postings sum to %', NEW."journal_id", unbalanced."currency", unbalanced."total";
+  END IF;
+
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER "postings_balanced"
+AFTER INSERT OR UPDATE ON "postings"
+DEFERRABLE INITIALLY DEFERRED
+FOR EACH ROW EXECUTE FUNCTION "check_journal_balanced"();
+
+INSERT INTO "ledger_accounts" ("code", "name", "type") VALUES
+  ('1000', 'Cash', 'asset'),
+  ('1500', 'Foreign exchange clearing', 'asset'),
+  ('1900', 'Suspense', 'asset'),
+  ('2000', 'Customer deposits', 'liability'),
+  ('3000', 'Equity', 'equity'),
+  ('4000', 'Fee income', 'income'),
+  ('5000', 'Interest expense', 'expense');
+
+-- book the existing transfers, every transfer debits the deposit of the sender and credits the deposit of the receiver.
+-- Transfers between currencies are balanced per currency by the foreign exchange clearing account
+INSERT INTO "journals" ("description", "transfer_id", "created_at")
+SELECT 'transfer ' || "id", "id", "created_at" FROM "transfers" ORDER BY "id";
+
+INSERT INTO "postings" ("journal_id", "ledger_account", "account_id", "amount", "currency", "created_at")
+SELECT "j"."id", '2000', "t"."from_account_id", "t"."amount", "fa"."currency", "t"."created_at"
+FROM "transfers" "t"
+JOIN "journals" "j" ON "j"."transfer_id" = "t"."id"
+JOIN "accounts" "fa" ON "fa"."id" = "t"."from_account_id"
+UNION ALL
+SELECT "j"."id", '2000', "t"."to_account_id", -"t"."to_amount", "ta"."currency", "t"."created_at"
+FROM "transfers" "t"
+JOIN "journals" "j" ON "j"."transfer_id" = "t"."id"
+JOIN "accounts" "ta" ON "ta"."id" = "t"."to_account_id"
+UNION ALL
+SELECT "j"."id", '1500', NULL, -"t"."amount", "fa"."currency", "t"."created_at"
+FROM "transfers" "t"
+JOIN "journals" "j" ON "j"."transfer_id" = "t"."id"
+JOIN "accounts" "fa" ON "fa"."id" = "t"."from_account_id"
+JOIN "accounts" "ta" ON "ta"."id" = "t"."to_account_id"
+WHERE "fa"."currency" <> "ta"."currency"
+UNION ALL
+SELECT "j"."id", '1500', NULL, "t"."to_amount", "ta"."currency", "t"."created_at"
+FROM "transfers" "t"
+JOIN "journals" "j" ON "j"."transfer_id" = "t"."id"
+JOIN "accounts" "fa" ON "fa"."id" = "t"."from_account_id"
+JOIN "accounts" "ta" ON "ta"."id" = "t"."to_account_id"
+WHERE "fa"."currency" <> "ta"."currency";
+
+-- balances that are not explained by transfers are booked against the suspense account until finance clears them
+WITH "opening" AS (
+  SELECT "a"."id", "a"."currency", "a"."balance" - COALESCE(sum("e"."amount"), 0) AS "amount"
+  FROM "accounts" "a"
+  LEFT JOIN "entries" "e" ON "e"."account_id" = "a"."id"
+  GROUP BY "a"."id"
+  HAVING "a"."balance" - COALESCE(sum("e"."amount"), 0) <> 0
+), "journal" AS (
+  INSERT INTO "journals" ("description")
+  SELECT 'opening balances' WHERE EXISTS (SELECT 1 FROM "opening")
+  RETURNING "id"
+)
+INSERT INTO "postings" ("journal_id", "ledger_account", "account_id", "amount", "currency")
+SELECT "journal"."id", '2000', "opening"."id", -"opening"."amount", "opening"."currency" FROM "journal", "opening"
+UNION ALL
+SELECT "journal"."id", '1900', NULL, "opening"."amount", "opening"."currency" FROM "journal", "opening";
+
+ALTER TABLE "entries" ADD COLUMN "transfer_id" bigint;
+
+ALTER TABLE "entries" ADD COLUMN "journal_id" bigint;
+
+ALTER TABLE "entries" ADD COLUMN "description" text NOT NULL DEFAULT '';
+
+ALTER TABLE "entries" ADD COLUMN "category" text NOT NULL DEFAULT '';
+
+CREATE INDEX ON "entries" ("transfer_id");
+
+COMMENT ON COLUMN "entries"."transfer_id" IS 'transfer that produced the entry, null for manual journals';
+
+COMMENT ON COLUMN "entries"."journal_id" IS 'journal that booked the entry';
+
+COMMENT ON COLUMN "entries"."category" IS 'e.g. transfer, reversal or the category of a manual journal';
+
+ALTER TABLE "entries" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "entries" ADD FOREIGN KEY ("journal_id") REFERENCES "journals" ("id");
+
+-- entries were created in the same database transaction as their transfer and share its timestamp
+UPDATE "entries" "e"
+SET
+  "transfer_id" = "t"."id",
+  "category" = CASE WHEN "t"."reversal_of" IS NULL THEN 'transfer' ELSE 'reversal' END,
+  "description" = CASE
+    WHEN "e"."amount" < 0 THEN 'transfer to account ' || "t"."to_account_id"
+    ELSE 'transfer from account ' || "t"."from_account_id"
+  END
+FROM "transfers" "t"
+WHERE
+  "e"."created_at" = "t"."created_at"
+  AND (
+    ("e"."account_id" = "t"."from_account_id" AND "e"."amount" = -"t"."amount")
+    OR
+    ("e"."account_id" = "t"."to_account_id" AND "e"."amount" = "t"."to_amount")
+  );
+
+UPDATE "entries" "e"
+SET "journal_id" = "j"."id"
+FROM "journals" "j"
+WHERE "j"."transfer_id" = "e"."transfer_id";
+
+-- balances that the general ledger migration booked against the suspense account had no entries, so that the balance
+-- of these accounts did not equal the sum of their entries
+INSERT INTO "entries" ("account_id", "amount", "journal_id", "description", "category", "created_at")
+SELECT "p"."account_id", -"p"."amount", "p"."journal_id", 'opening balance', 'opening_balance', "j"."created_at"
+FROM "postings" "p"
+JOIN "journals" "j" ON "j"."id" = "p"."journal_id"
+WHERE
+  "j"."description" = 'opening balances'
+  AND "j"."transfer_id" IS NULL
+  AND "p"."account_id" IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM "entries" "e" WHERE "e"."journal_id" = "p"."journal_id");
+
+CREATE TABLE "balance_snapshots" (
+  "account_id" bigint NOT NULL,
+  "end_time" timestamptz NOT NULL,
+  "balance" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("account_id", "end_time")
+);
+
+COMMENT ON TABLE "balance_snapshots" IS 'daily balances of the accounts, computed from the entries';
+
+COMMENT ON COLUMN "balance_snapshots"."end_time" IS 'start of the next day in UTC, the balance is the sum of the entries created before';
+
+CREATE INDEX ON "balance_snapshots" ("end_time");
+
+ALTER TABLE "balance_snapshots" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+
+CREATE INDEX "entries_account_id_created_at_idx" ON "entries" ("account_id", "created_at");
+
+-- the postings of a manual journal on a customer account are explained by the entries of the same journal. Opening
+-- balances that the general ledger migration booked without entries get the missing entry, linked by the journal of
+-- their postings. Transfer journals are left to the reconciliation of the transfers
+WITH "missing" AS (
+  SELECT
+    "p"."journal_id",
+    "p"."account_id",
+    -sum("p"."amount") - COALESCE((
+      SELECT sum("e"."amount")
+      FROM "entries" "e"
+      WHERE "e"."journal_id" = "p"."journal_id" AND "e"."account_id" = "p"."account_id"
+    ), 0) AS "amount"
+  FROM "postings" "p"
+  JOIN "journals" "j" ON "j"."id" = "p"."journal_id"
+  WHERE "p"."account_id" IS NOT NULL AND "j"."transfer_id" IS NULL
+  GROUP BY "p"."journal_id", "p"."account_id"
+)
+INSERT INTO "entries" ("account_id", "amount", "journal_id", "description", "category", "created_at")
+SELECT "m"."account_id", "m"."amount", "m"."journal_id", 'opening balance', 'opening_balance', "j"."created_at"
+FROM "missing" "m"
+JOIN "journals" "j" ON "j"."id" = "m"."journal_id"
+WHERE "m"."amount" <> 0;
+
+ALTER TABLE "transfers" ALTER COLUMN "exchange_rate" DROP DEFAULT;
+
+ALTER TABLE "transfers" ALTER COLUMN "exchange_rate" TYPE bigint USING round("exchange_rate" * 100000000)::bigint;
+
+ALTER TABLE "transfers" ALTER COLUMN "exchange_rate" SET DEFAULT 100000000;
+
+COMMENT ON COLUMN "transfers"."exchange_rate" IS 'rate used to convert amount into to_amount, in units of 10^-8';
+
+ALTER TABLE "idempotency_keys" ADD COLUMN "locked_until" timestamptz;
+
+-- reservations of requests that were in progress during the migration can be taken over right away
+UPDATE "idempotency_keys" SET "locked_until" = now() WHERE "response_body" IS NULL;
+
+COMMENT ON COLUMN "idempotency_keys"."locked_until" IS 'lease of the request in progress, another request with the key can take over after it, null once the response is stored';
\ No newline at end of file